@@ -39,6 +39,7 @@ import (
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
@@ -49,6 +50,12 @@ var (
 	metricsHost       = "0.0.0.0"
 	metricsPort int32 = 8383
 )
+
+// Change below variables to serve health probes on different host or port.
+var (
+	healthProbeHost       = "0.0.0.0"
+	healthProbePort int32 = 8081
+)
 var log = logf.Log.WithName("cmd")
 
 func printVersion() {
@@ -58,9 +65,6 @@ func printVersion() {
 }
 
 func main() {
-	go restserver.StartRestServer()
-	log.Info("Starting Rest server in Operator side")
-
 	// Add the zap logger flag set to the CLI. The flag set must
 	// be added before calling pflag.Parse().
 	pflag.CommandLine.AddFlagSet(zap.FlagSet())
@@ -87,6 +91,10 @@ func main() {
 			"env_var", operatorConfig.SystemNamespaceEnv, "default_ns", operatorConfig.DefaultSystemNamespace)
 	}
 	operatorConfig.SetOperatorNamespace()
+	if _, err := operatorConfig.SetBundleDebounceWindowFromEnv(); err != nil {
+		log.Error(err, "Invalid debounce window of the API bundle", "env_var", operatorConfig.BundleDebounceWindowEnv)
+		os.Exit(1)
+	}
 
 	printVersion()
 
@@ -108,8 +116,9 @@ func main() {
 
 	// Create a new Cmd to provide shared dependencies and start components
 	mgr, err := manager.New(cfg, manager.Options{
-		Namespace:          operatorConfig.GetWatchNamespaces(),
-		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		Namespace:              operatorConfig.GetWatchNamespaces(),
+		MetricsBindAddress:     fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		HealthProbeBindAddress: fmt.Sprintf("%s:%d", healthProbeHost, healthProbePort),
 	})
 
 	if err != nil {
@@ -137,6 +146,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup the Rest server to send APIs to the MGW Adapter
+	restServer := restserver.NewRestServer(mgr.GetClient(), mgr.GetAPIReader())
+	if err := mgr.Add(restServer); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Setup health probes
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("rest-server", restServer.ReadyzCheck); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	// Create Service object to expose the metrics port.
 	_, err = metrics.CreateMetricsService(ctx, cfg, []v1.ServicePort{{Port: metricsPort}})
 	if err != nil {
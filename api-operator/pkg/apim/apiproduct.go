@@ -0,0 +1,181 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var logProduct = log.Log.WithName("apim.product")
+
+const (
+	defaultProductVersion    = "1.0.0"
+	defaultProductVisibility = "PUBLIC"
+)
+
+// SyncAPIProduct creates or updates the API product in APIM. apiIds are the IDs of the APIs in APIM keyed by the
+// names of the API resources referred by the product. Returns the ID of the API product in APIM.
+func SyncAPIProduct(client *client.Client, product *wso2v1alpha2.APIProduct, apiIds map[string]string) (string,
+	error) {
+	var productId string
+	err := withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		apimProduct, err := toAPIProduct(httpClient, accessToken, apimConfig.PublisherEndpoint, product, apiIds)
+		if err != nil {
+			return err
+		}
+		productId, err = applyAPIProduct(httpClient, accessToken,
+			apimConfig.PublisherEndpoint+"/"+apiProductsEndpointSuffix, apimProduct)
+		return err
+	})
+	return productId, err
+}
+
+// DeleteAPIProduct deletes the API product with the given ID from APIM. Deleting an API product that does not exist
+// is not an error.
+func DeleteAPIProduct(client *client.Client, productId string) error {
+	return withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		endpoint := apimConfig.PublisherEndpoint + "/" + apiProductsEndpointSuffix + "/" + url.PathEscape(productId)
+		return deleteResource(httpClient, accessToken, endpoint, "delete API product")
+	})
+}
+
+// APIProductName returns the name of the given API product in APIM
+func APIProductName(product *wso2v1alpha2.APIProduct) string {
+	if product.Spec.Name != "" {
+		return product.Spec.Name
+	}
+	return product.Namespace + "-" + product.Name
+}
+
+// toAPIProduct returns the APIM API product of the given APIProduct with the default values. All the operations of
+// an API are exposed if no resources of the API are specified.
+func toAPIProduct(httpClient *resty.Client, accessToken, endpoint string, product *wso2v1alpha2.APIProduct,
+	apiIds map[string]string) (*APIProduct, error) {
+	apimProduct := &APIProduct{
+		Name:        APIProductName(product),
+		Context:     product.Spec.Context,
+		Version:     product.Spec.Version,
+		Description: product.Spec.Description,
+		Policies:    product.Spec.Tiers,
+		Visibility:  defaultProductVisibility,
+	}
+	if apimProduct.Version == "" {
+		apimProduct.Version = defaultProductVersion
+	}
+	if len(apimProduct.Policies) == 0 {
+		apimProduct.Policies = []string{defaultThrottlingTier}
+	}
+
+	for _, api := range product.Spec.APIs {
+		apiId, ok := apiIds[api.Name]
+		if !ok {
+			return nil, fmt.Errorf("ID of the API %q in APIM is not resolved", api.Name)
+		}
+		productAPI := APIProductAPI{APIID: apiId}
+		for _, resource := range api.Resources {
+			productAPI.Operations = append(productAPI.Operations, APIOperation{Target: resource.Path,
+				Verb: resource.Verb})
+		}
+		if len(productAPI.Operations) == 0 {
+			operations, err := getAPIOperations(httpClient, accessToken, endpoint, apiId)
+			if err != nil {
+				return nil, err
+			}
+			productAPI.Operations = operations
+		}
+		apimProduct.APIs = append(apimProduct.APIs, productAPI)
+	}
+	return apimProduct, nil
+}
+
+// applyAPIProduct creates the API product if an API product with the same name does not exist, otherwise updates
+// it. Returns the ID of the API product.
+func applyAPIProduct(httpClient *resty.Client, accessToken, endpoint string, product *APIProduct) (string, error) {
+	headers := jsonRequestHeaders(accessToken)
+	resp, err := invokeGETRequest(httpClient, endpoint+"?query="+url.QueryEscape(fmt.Sprintf("name:\"%s\"",
+		product.Name)), headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", httpclient.NewStatusError("get API products", resp)
+	}
+	productList := &APIProductListResponse{}
+	if err := json.Unmarshal(resp.Body(), productList); err != nil {
+		return "", err
+	}
+
+	for _, existing := range productList.List {
+		// query matches the API products partially
+		if existing.Name != product.Name {
+			continue
+		}
+		product.ID = existing.ID
+		logProduct.Info("Updating the API product", "name", product.Name, "id", product.ID)
+		resp, err = invokePUTRequest(httpClient, endpoint+"/"+url.PathEscape(product.ID), headers, product)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return "", httpclient.NewStatusError("update API product", resp)
+		}
+		return product.ID, nil
+	}
+
+	logProduct.Info("Creating the API product", "name", product.Name)
+	resp, err = invokePOSTRequest(httpClient, endpoint, headers, product)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return "", httpclient.NewStatusError("create API product", resp)
+	}
+	created := &APIProduct{}
+	if err := json.Unmarshal(resp.Body(), created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// getAPIOperations returns the operations of the API with the given ID
+func getAPIOperations(httpClient *resty.Client, accessToken, endpoint, apiId string) ([]APIOperation, error) {
+	resp, err := invokeGETRequest(httpClient, endpoint+"/"+defaultApiListEndpointSuffix+"/"+url.PathEscape(apiId),
+		jsonRequestHeaders(accessToken))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, httpclient.NewStatusError("get API", resp)
+	}
+	api := &APIOperationsResponse{}
+	if err := json.Unmarshal(resp.Body(), api); err != nil {
+		return nil, err
+	}
+	operations := make([]APIOperation, 0, len(api.Operations))
+	for _, operation := range api.Operations {
+		operations = append(operations, APIOperation{Target: operation.Target, Verb: operation.Verb})
+	}
+	return operations, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestToAPIProduct(t *testing.T) {
+
+	product := &wso2v1alpha2.APIProduct{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "product"},
+		Spec: wso2v1alpha2.APIProductSpec{
+			Context: "/product",
+			APIs: []wso2v1alpha2.APIProductAPI{{
+				Name:      "petstore",
+				Resources: []wso2v1alpha2.APIProductResource{{Path: "/pet/{petId}", Verb: "GET"}},
+			}},
+		},
+	}
+	apimProduct, err := toAPIProduct(nil, "", "", product, map[string]string{"petstore": "api-id"})
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if apimProduct.Name != "ns-product" || apimProduct.Version != defaultProductVersion ||
+		len(apimProduct.Policies) != 1 || apimProduct.Policies[0] != defaultThrottlingTier {
+		t.Errorf("expected the default name, version and policies but was %+v", apimProduct)
+	}
+	if len(apimProduct.APIs) != 1 || apimProduct.APIs[0].APIID != "api-id" ||
+		len(apimProduct.APIs[0].Operations) != 1 || apimProduct.APIs[0].Operations[0].Target != "/pet/{petId}" {
+		t.Errorf("expected the resources of the API in the spec but was %+v", apimProduct.APIs)
+	}
+
+	if _, err := toAPIProduct(nil, "", "", product, map[string]string{}); err == nil {
+		t.Error("expected an error for an API with unresolved ID")
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sort"
+)
+
+var logApp = log.Log.WithName("apim.application")
+
+const (
+	defaultThrottlingTier = "Unlimited"
+	defaultTokenType      = "JWT"
+	defaultKeyType        = "PRODUCTION"
+	defaultGrantType      = "client_credentials"
+	// defaultKeyValidityTime is the validity time in seconds of the access tokens issued with the application keys
+	defaultKeyValidityTime = "3600"
+)
+
+// SyncApplication creates or updates the application in APIM and generates its keys. Returns the ID and the keys of
+// the application in APIM.
+func SyncApplication(client *client.Client, app *wso2v1alpha2.Application) (string, *ApplicationKey, error) {
+	var appId string
+	var key *ApplicationKey
+	err := withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		var err error
+		endpoint := apimConfig.DevportalEndpoint + "/" + devportalApplicationsEndpoint
+		if appId, err = applyApplication(httpClient, accessToken, endpoint, toApplication(app)); err != nil {
+			return err
+		}
+		key, err = applyApplicationKey(httpClient, accessToken, endpoint+"/"+appId, app.Spec.Keys)
+		return err
+	})
+	return appId, key, err
+}
+
+// DeleteApplication deletes the application with the given ID from APIM. Deleting an application that does not exist
+// is not an error.
+func DeleteApplication(client *client.Client, appId string) error {
+	return withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		endpoint := apimConfig.DevportalEndpoint + "/" + devportalApplicationsEndpoint + "/" + url.PathEscape(appId)
+		return deleteResource(httpClient, accessToken, endpoint, "delete application")
+	})
+}
+
+// ApplicationName returns the name of the given application in APIM
+func ApplicationName(app *wso2v1alpha2.Application) string {
+	if app.Spec.Name != "" {
+		return app.Spec.Name
+	}
+	return app.Namespace + "-" + app.Name
+}
+
+// toApplication returns the APIM application of the given Application with the default values
+func toApplication(app *wso2v1alpha2.Application) *Application {
+	application := &Application{
+		Name:             ApplicationName(app),
+		ThrottlingPolicy: app.Spec.ThrottlingTier,
+		Description:      app.Spec.Description,
+		TokenType:        app.Spec.TokenType,
+		Attributes:       app.Spec.Attributes,
+	}
+	if application.ThrottlingPolicy == "" {
+		application.ThrottlingPolicy = defaultThrottlingTier
+	}
+	if application.TokenType == "" {
+		application.TokenType = defaultTokenType
+	}
+	return application
+}
+
+// applyApplication creates the application if an application with the same name does not exist, otherwise updates
+// it. Returns the ID of the application.
+func applyApplication(httpClient *resty.Client, accessToken, endpoint string, app *Application) (string, error) {
+	headers := jsonRequestHeaders(accessToken)
+	resp, err := invokeGETRequest(httpClient, endpoint+"?query="+url.QueryEscape(app.Name), headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", httpclient.NewStatusError("get applications", resp)
+	}
+	appList := &ApplicationListResponse{}
+	if err := json.Unmarshal(resp.Body(), appList); err != nil {
+		return "", err
+	}
+
+	for _, existing := range appList.List {
+		// query matches the applications partially
+		if existing.Name != app.Name {
+			continue
+		}
+		app.ApplicationID = existing.ApplicationID
+		logApp.Info("Updating the application", "name", app.Name, "id", app.ApplicationID)
+		resp, err = invokePUTRequest(httpClient, endpoint+"/"+url.PathEscape(app.ApplicationID), headers, app)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return "", httpclient.NewStatusError("update application", resp)
+		}
+		return app.ApplicationID, nil
+	}
+
+	logApp.Info("Creating the application", "name", app.Name)
+	resp, err = invokePOSTRequest(httpClient, endpoint, headers, app)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return "", httpclient.NewStatusError("create application", resp)
+	}
+	created := &Application{}
+	if err := json.Unmarshal(resp.Body(), created); err != nil {
+		return "", err
+	}
+	return created.ApplicationID, nil
+}
+
+// applyApplicationKey generates the keys of the given type for the application if they are not generated,
+// otherwise updates the grant types of the keys. appEndpoint is the endpoint of the application.
+func applyApplicationKey(httpClient *resty.Client, accessToken, appEndpoint string,
+	keys wso2v1alpha2.ApplicationKeys) (*ApplicationKey, error) {
+	keyType := keys.KeyType
+	if keyType == "" {
+		keyType = defaultKeyType
+	}
+	grantTypes := append([]string{}, keys.GrantTypes...)
+	if len(grantTypes) == 0 {
+		grantTypes = []string{defaultGrantType}
+	}
+	sort.Strings(grantTypes)
+
+	headers := jsonRequestHeaders(accessToken)
+	resp, err := invokeGETRequest(httpClient, appEndpoint+"/"+oauthKeysEndpointSuffix, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, httpclient.NewStatusError("get application keys", resp)
+	}
+	keyList := &ApplicationKeyListResponse{}
+	if err := json.Unmarshal(resp.Body(), keyList); err != nil {
+		return nil, err
+	}
+
+	for _, key := range keyList.List {
+		if key.KeyType != keyType {
+			continue
+		}
+		existingGrantTypes := append([]string{}, key.SupportedGrantTypes...)
+		sort.Strings(existingGrantTypes)
+		if reflect.DeepEqual(existingGrantTypes, grantTypes) {
+			return &key, nil
+		}
+
+		logApp.Info("Updating the grant types of the application keys", "key_type", keyType)
+		key.SupportedGrantTypes = grantTypes
+		resp, err = invokePUTRequest(httpClient, appEndpoint+"/"+oauthKeysEndpointSuffix+"/"+
+			url.PathEscape(key.KeyMappingID), headers, key)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, httpclient.NewStatusError("update application keys", resp)
+		}
+		return unmarshalApplicationKey(resp)
+	}
+
+	logApp.Info("Generating the application keys", "key_type", keyType)
+	resp, err = invokePOSTRequest(httpClient, appEndpoint+"/"+generateKeysEndpointSuffix, headers,
+		&ApplicationKeyGenerateRequest{
+			KeyType:                 keyType,
+			GrantTypesToBeSupported: grantTypes,
+			ValidityTime:            defaultKeyValidityTime,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, httpclient.NewStatusError("generate application keys", resp)
+	}
+	return unmarshalApplicationKey(resp)
+}
+
+func unmarshalApplicationKey(resp *resty.Response) (*ApplicationKey, error) {
+	key := &ApplicationKey{}
+	if err := json.Unmarshal(resp.Body(), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// deleteResource deletes the resource in the given endpoint of the REST API. Deleting a resource that does not exist
+// is not an error.
+func deleteResource(httpClient *resty.Client, accessToken, endpoint, op string) error {
+	resp, err := invokeDELETERequest(httpClient, endpoint, jsonRequestHeaders(accessToken))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNotFound {
+		return httpclient.NewStatusError(op, resp)
+	}
+	return nil
+}
+
+// jsonRequestHeaders returns the headers of a request to the REST API with a JSON payload
+func jsonRequestHeaders(accessToken string) map[string]string {
+	headers := make(map[string]string)
+	headers[HeaderAuthorization] = HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[HeaderContentType] = HeaderValueApplicationJSON
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+	return headers
+}
@@ -0,0 +1,40 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestToApplication(t *testing.T) {
+
+	app := &wso2v1alpha2.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "app"}}
+	application := toApplication(app)
+	if application.Name != "ns-app" || application.ThrottlingPolicy != defaultThrottlingTier ||
+		application.TokenType != defaultTokenType {
+		t.Errorf("expected the default name, throttling tier and token type but was %+v", application)
+	}
+
+	app.Spec = wso2v1alpha2.ApplicationSpec{Name: "petstore", ThrottlingTier: "10PerMin", TokenType: "OAUTH"}
+	application = toApplication(app)
+	if application.Name != "petstore" || application.ThrottlingPolicy != "10PerMin" ||
+		application.TokenType != "OAUTH" {
+		t.Errorf("expected the name, throttling tier and token type in the spec but was %+v", application)
+	}
+}
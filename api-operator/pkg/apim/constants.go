@@ -16,6 +16,8 @@
 
 package apim
 
+import "time"
+
 const (
 	apimConfName                  = "apim-config"
 	clientRegistrationSecret      = "ckcs-secret"
@@ -24,8 +26,11 @@ const (
 	apimRegistrationEndpointConst = "apimKeymanagerEndpoint"
 	apimPublisherEndpointConst    = "apimPublisherEndpoint"
 	apimTokenEndpointConst        = "apimTokenEndpoint"
+	apimDevportalEndpointConst    = "apimDevportalEndpoint"
 	apimCredentialsConst          = "apimCredentialsSecret"
 	skipVerifyConst               = "insecureSkipVerify"
+	certSecurityConst             = "cert_security"
+	apimTarget                    = "apim"
 
 	HeaderAuthorization           = "Authorization"
 	HeaderAccept                  = "Accept"
@@ -37,14 +42,30 @@ const (
 	HeaderValueKeepAlive          = "keep-alive"
 	HeaderValueXWWWFormUrlEncoded = "application/x-www-form-urlencoded"
 	DefaultHttpRequestTimeout     = 10000
-	Deployment                    = "Deployment"
-	Certificates                  = "certificates"
 
 	publisherAPIImportEndpoint              = "api/am/publisher/v2/apis/import?overwrite=true"
 	defaultClientRegistrationEndpointSuffix = "client-registration/v0.17/register"
 	defaultApiListEndpointSuffix            = "api/am/publisher/v2/apis"
 	defaultTokenEndpoint                    = "oauth2/token"
 	importAPIFromSwaggerEndpoint            = "api/am/publisher/v2/apis/import-openapi"
+	changeLifecycleEndpoint                 = "api/am/publisher/v2/apis/change-lifecycle"
+	lifecycleStateEndpointSuffix            = "lifecycle-state"
+	devportalApplicationsEndpoint           = "api/am/devportal/v2/applications"
+	devportalSubscriptionsEndpoint          = "api/am/devportal/v2/subscriptions"
+	generateKeysEndpointSuffix              = "generate-keys"
+	oauthKeysEndpointSuffix                 = "oauth-keys"
+	apiProductsEndpointSuffix               = "api/am/publisher/v2/api-products"
+	revisionsEndpointSuffix                 = "revisions"
+	deployRevisionEndpointSuffix            = "deploy-revision"
+	undeployRevisionEndpointSuffix          = "undeploy-revision"
+
+	// maxAPIRevisions is the maximum number of revisions of an API allowed by APIM
+	maxAPIRevisions = 5
+
+	tokenScopes = "apim:api_import_export apim:api_view apim:api_create apim:api_delete apim:api_publish " +
+		"apim:subscribe apim:app_manage apim:sub_manage"
+	// tokenExpiryMargin is the duration before the expiry of an access token to request a new one
+	tokenExpiryMargin = 60 * time.Second
 )
 
 type API struct {
@@ -56,14 +77,117 @@ type API struct {
 	LifeCycleStatus string `json:"lifeCycleStatus"`
 }
 
+type LifecycleStateResponse struct {
+	State                string                `json:"state"`
+	AvailableTransitions []LifecycleTransition `json:"availableTransitions"`
+}
+
+type LifecycleTransition struct {
+	Event       string `json:"event"`
+	TargetState string `json:"targetState"`
+}
+
 type APIListResponse struct {
 	Count int32 `json:"count"`
 	List  []API `json:"list"`
 }
 
+type Application struct {
+	ApplicationID    string            `json:"applicationId,omitempty"`
+	Name             string            `json:"name"`
+	ThrottlingPolicy string            `json:"throttlingPolicy"`
+	Description      string            `json:"description,omitempty"`
+	TokenType        string            `json:"tokenType,omitempty"`
+	Attributes       map[string]string `json:"attributes,omitempty"`
+}
+
+type ApplicationListResponse struct {
+	Count int32         `json:"count"`
+	List  []Application `json:"list"`
+}
+
+type ApplicationKey struct {
+	KeyMappingID        string   `json:"keyMappingId,omitempty"`
+	KeyType             string   `json:"keyType"`
+	ConsumerKey         string   `json:"consumerKey,omitempty"`
+	ConsumerSecret      string   `json:"consumerSecret,omitempty"`
+	SupportedGrantTypes []string `json:"supportedGrantTypes,omitempty"`
+}
+
+type ApplicationKeyListResponse struct {
+	Count int32            `json:"count"`
+	List  []ApplicationKey `json:"list"`
+}
+
+type ApplicationKeyGenerateRequest struct {
+	KeyType                 string   `json:"keyType"`
+	GrantTypesToBeSupported []string `json:"grantTypesToBeSupported"`
+	ValidityTime            string   `json:"validityTime"`
+}
+
+type Subscription struct {
+	SubscriptionID   string `json:"subscriptionId,omitempty"`
+	ApplicationID    string `json:"applicationId"`
+	APIID            string `json:"apiId"`
+	ThrottlingPolicy string `json:"throttlingPolicy"`
+	Status           string `json:"status,omitempty"`
+}
+
+type SubscriptionListResponse struct {
+	Count int32          `json:"count"`
+	List  []Subscription `json:"list"`
+}
+
+type APIProduct struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Context     string          `json:"context"`
+	Version     string          `json:"version"`
+	Description string          `json:"description,omitempty"`
+	Policies    []string        `json:"policies"`
+	Visibility  string          `json:"visibility"`
+	APIs        []APIProductAPI `json:"apis"`
+}
+
+type APIProductAPI struct {
+	APIID      string         `json:"apiId"`
+	Operations []APIOperation `json:"operations"`
+}
+
+type APIOperation struct {
+	Target string `json:"target"`
+	Verb   string `json:"verb"`
+}
+
+type APIProductListResponse struct {
+	Count int32        `json:"count"`
+	List  []APIProduct `json:"list"`
+}
+
+type APIOperationsResponse struct {
+	Operations []APIOperation `json:"operations"`
+}
+
+type APIRevision struct {
+	ID             string                  `json:"id,omitempty"`
+	Description    string                  `json:"description,omitempty"`
+	DeploymentInfo []APIRevisionDeployment `json:"deploymentInfo,omitempty"`
+}
+
+type APIRevisionListResponse struct {
+	Count int32         `json:"count"`
+	List  []APIRevision `json:"list"`
+}
+
+type APIRevisionDeployment struct {
+	Name               string `json:"name"`
+	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+}
+
 type RESTConfig struct {
 	KeyManagerEndpoint    string
 	PublisherEndpoint     string
+	DevportalEndpoint     string
 	TokenEndpoint         string
 	CredentialsSecretName string
 	SkipVerification      bool
@@ -17,11 +17,14 @@
 package apim
 
 import (
+	"net/http"
 	"strings"
 
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	"gopkg.in/resty.v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -48,14 +51,17 @@ func DeleteImportedAPI(client *client.Client, instance *wso2v1alpha2.API) error
 	tokenEndpoint := apimConfig.TokenEndpoint
 	credSecret := apimConfig.CredentialsSecretName
 
-	insecure = apimConfig.SkipVerification
+	httpClient, errClient := getAPIMClient(client, apimConfig)
+	if errClient != nil {
+		return errClient
+	}
 
 	if strings.EqualFold(tokenEndpoint, "") {
 		tokenEndpoint = kmEndpoint + "/" + defaultTokenEndpoint
 		logDelete.Info("Token endpoint not defined. Using keymanager endpoint.", "tokenEndpoint", tokenEndpoint)
 	}
 
-	accessToken, errToken := getAccessToken(client, tokenEndpoint, kmEndpoint, credSecret)
+	accessToken, errToken := getAccessToken(client, httpClient, tokenEndpoint, kmEndpoint, credSecret)
 	if errToken != nil {
 		return errToken
 	}
@@ -76,14 +82,29 @@ func DeleteImportedAPI(client *client.Client, instance *wso2v1alpha2.API) error
 		}
 	}
 
+	deleteErr := deleteAPI(httpClient, inputConf, accessToken, publisherEndpoint)
+	if httpclient.HasStatus(deleteErr, http.StatusUnauthorized) {
+		logDelete.Info("Access token is rejected by APIM, retrying with a new access token")
+		invalidateAccessToken(accessToken)
+		accessToken, errToken = getAccessToken(client, httpClient, tokenEndpoint, kmEndpoint, credSecret)
+		if errToken != nil {
+			return errToken
+		}
+		deleteErr = deleteAPI(httpClient, inputConf, accessToken, publisherEndpoint)
+	}
+	return deleteErr
+}
+
+// deleteAPI deletes the API in the given project zip or swagger from APIM with the given access token
+func deleteAPI(httpClient *resty.Client, inputConf *corev1.ConfigMap, accessToken string, publisherEndpoint string) error {
 	if inputConf.BinaryData != nil {
-		deleteErr := deleteAPIFromProject(inputConf, accessToken, publisherEndpoint)
+		deleteErr := deleteAPIFromProject(httpClient, inputConf, accessToken, publisherEndpoint)
 		if deleteErr != nil {
 			logDelete.Error(deleteErr, "Error when deleting the API using zip")
 			return deleteErr
 		}
 	} else {
-		deleteErr := deleteAPIFromSwagger(inputConf, accessToken, publisherEndpoint)
+		deleteErr := deleteAPIFromSwagger(httpClient, inputConf, accessToken, publisherEndpoint)
 		if deleteErr != nil {
 			logDelete.Error(deleteErr, "Error when deleting the API using swagger")
 			return deleteErr
@@ -92,7 +113,7 @@ func DeleteImportedAPI(client *client.Client, instance *wso2v1alpha2.API) error
 	return nil
 }
 
-func deleteAPIFromProject(config *corev1.ConfigMap, token string, endpoint string) error {
+func deleteAPIFromProject(httpClient *resty.Client, config *corev1.ConfigMap, token string, endpoint string) error {
 	zipFileName, errZip := maps.OneKey(config.BinaryData)
 	if errZip != nil {
 		return errZip
@@ -113,20 +134,21 @@ func deleteAPIFromProject(config *corev1.ConfigMap, token string, endpoint strin
 	}
 
 	// checks whether the API exists in APIM
-	apiId, err := getAPIId(token, endpoint+"/"+defaultApiListEndpointSuffix, apiInfo.Data.Name, apiInfo.Data.Version)
+	apiId, err := getAPIId(httpClient, token, endpoint+"/"+defaultApiListEndpointSuffix, apiInfo.Data.Name, apiInfo.Data.Version)
 	if err != nil {
 		return err
 	}
 
-	deleteErr := deleteAPIById(endpoint, apiId, token)
+	deleteErr := deleteAPIById(httpClient, endpoint, apiId, token)
 	if deleteErr != nil {
 		logDelete.Error(deleteErr, "Error when deleting the API from APIM")
+		return deleteErr
 	}
 
 	return nil
 }
 
-func deleteAPIFromSwagger(config *corev1.ConfigMap, token string, endpoint string) error {
+func deleteAPIFromSwagger(httpClient *resty.Client, config *corev1.ConfigMap, token string, endpoint string) error {
 	swaggerFileName, errSwagger := maps.OneKey(config.Data)
 	if errSwagger != nil {
 		logImport.Error(errSwagger, "Error in the swagger configmap data", "data", config.Data)
@@ -140,12 +162,12 @@ func deleteAPIFromSwagger(config *corev1.ConfigMap, token string, endpoint strin
 		return err
 	}
 
-	apiId, err := getAPIId(token, endpoint+"/"+defaultApiListEndpointSuffix, name, version)
+	apiId, err := getAPIId(httpClient, token, endpoint+"/"+defaultApiListEndpointSuffix, name, version)
 	if err != nil {
 		return err
 	}
 
-	deleteErr := deleteAPIById(endpoint, apiId, token)
+	deleteErr := deleteAPIById(httpClient, endpoint, apiId, token)
 	if deleteErr != nil {
 		logDelete.Error(deleteErr, "Error when deleting the API from APIM")
 		return deleteErr
@@ -0,0 +1,89 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"gopkg.in/resty.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const testSwagger = `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-basePath: /products
+x-wso2-production-endpoints:
+  urls:
+    - https://products.example.com
+paths:
+  /products:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func TestImportSecuredSwaggerAPI(t *testing.T) {
+	swaggerCM := k8s.NewConfMap()
+	swaggerCM.Namespace, swaggerCM.Name = "ns", "products-swagger"
+	swaggerCM.Data = map[string]string{"swagger.yaml": testSwagger}
+	backendSecret := k8s.NewSecret()
+	backendSecret.Namespace, backendSecret.Name = "ns", "backend"
+	backendSecret.Data = map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")}
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(s)
+	var cl client.Client = fake.NewFakeClientWithScheme(s, swaggerCM, backendSecret)
+
+	imported := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/"+defaultApiListEndpointSuffix:
+			apis := &APIListResponse{}
+			if imported {
+				apis.Count, apis.List = 1, []API{{ID: "api-id", Name: "Products", Version: "1.0.0"}}
+			}
+			_ = json.NewEncoder(w).Encode(apis)
+		case r.Method == http.MethodPost && r.URL.Path == "/"+importAPIFromSwaggerEndpoint:
+			imported = true
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// the endpoint security is not a params configmap, hence the swagger definition is imported with it
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "products"}}
+	api.Spec.SwaggerConfigMapName = swaggerCM.Name
+	api.Spec.EndpointSecurity = &wso2v1alpha2.EndpointSecurity{Type: "basic", SecretName: "backend"}
+	apiId, err := importAPI(&cl, resty.New(), api, "token", server.URL)
+	if err != nil || !imported || apiId != "api-id" {
+		t.Errorf("expected the API with endpoint security to be imported but was %q, %v", apiId, err)
+	}
+}
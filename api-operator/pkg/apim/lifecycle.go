@@ -0,0 +1,169 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var logLifecycle = log.Log.WithName("apim.lifecycle")
+
+// lifecycleTransitions are the transitions between the states of the default API lifecycle of APIM. They are used
+// to find the intermediate states to reach a state that is not directly reachable from the current state.
+var lifecycleTransitions = map[wso2v1alpha2.LifecycleState][]wso2v1alpha2.LifecycleState{
+	wso2v1alpha2.LifecycleCreated:    {wso2v1alpha2.LifecyclePublished, wso2v1alpha2.LifecyclePrototyped},
+	wso2v1alpha2.LifecyclePrototyped: {wso2v1alpha2.LifecyclePublished, wso2v1alpha2.LifecycleCreated},
+	wso2v1alpha2.LifecyclePublished: {wso2v1alpha2.LifecycleBlocked, wso2v1alpha2.LifecycleDeprecated,
+		wso2v1alpha2.LifecyclePrototyped, wso2v1alpha2.LifecycleCreated},
+	wso2v1alpha2.LifecycleBlocked:    {wso2v1alpha2.LifecyclePublished, wso2v1alpha2.LifecycleDeprecated},
+	wso2v1alpha2.LifecycleDeprecated: {wso2v1alpha2.LifecycleRetired},
+	wso2v1alpha2.LifecycleRetired:    {},
+}
+
+// ChangeLifecycleState changes the lifecycle state of the API with the given ID in APIM to the given state, through
+// the intermediate states if the state is not directly reachable. Returns the lifecycle state of the API after the
+// change, which is also returned with the error if the change is failed.
+func ChangeLifecycleState(client *client.Client, apiId string, state wso2v1alpha2.LifecycleState) (
+	wso2v1alpha2.LifecycleState, error) {
+	var current wso2v1alpha2.LifecycleState
+	err := withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		var err error
+		current, err = changeLifecycleState(httpClient, accessToken, apimConfig.PublisherEndpoint, apiId, state)
+		return err
+	})
+	return current, err
+}
+
+// changeLifecycleState changes the lifecycle state of the API with the given access token
+func changeLifecycleState(httpClient *resty.Client, accessToken, endpoint, apiId string,
+	state wso2v1alpha2.LifecycleState) (wso2v1alpha2.LifecycleState, error) {
+	lcState, err := getLifecycleState(httpClient, accessToken, endpoint, apiId)
+	if err != nil {
+		return "", err
+	}
+	current := wso2v1alpha2.LifecycleState(lcState.State)
+
+	path := lifecyclePath(current, state)
+	if path == nil {
+		// the state may be directly reachable in a customized lifecycle
+		path = []wso2v1alpha2.LifecycleState{state}
+	}
+	for _, next := range path {
+		if current == state {
+			break
+		}
+		event := ""
+		for _, transition := range lcState.AvailableTransitions {
+			if transition.TargetState == string(next) {
+				event = transition.Event
+				break
+			}
+		}
+		if event == "" {
+			return current, fmt.Errorf("lifecycle state %q of the API is not reachable from the state %q", next,
+				current)
+		}
+
+		logLifecycle.Info("Changing the lifecycle state of the API", "api_id", apiId, "from", current, "to", next,
+			"action", event)
+		if err = invokeLifecycleAction(httpClient, accessToken, endpoint, apiId, event); err != nil {
+			return current, err
+		}
+		if lcState, err = getLifecycleState(httpClient, accessToken, endpoint, apiId); err != nil {
+			return current, err
+		}
+		current = wso2v1alpha2.LifecycleState(lcState.State)
+	}
+
+	if current != state {
+		return current, fmt.Errorf("lifecycle state %q of the API is not reachable from the state %q", state, current)
+	}
+	return current, nil
+}
+
+// lifecyclePath returns the states to go through from the state "from" to reach the state "to", excluding the
+// state "from". Returns nil if the state is not reachable.
+func lifecyclePath(from, to wso2v1alpha2.LifecycleState) []wso2v1alpha2.LifecycleState {
+	previous := map[wso2v1alpha2.LifecycleState]wso2v1alpha2.LifecycleState{from: ""}
+	queue := []wso2v1alpha2.LifecycleState{from}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		if state == to {
+			var path []wso2v1alpha2.LifecycleState
+			for ; state != from; state = previous[state] {
+				path = append([]wso2v1alpha2.LifecycleState{state}, path...)
+			}
+			return path
+		}
+		for _, next := range lifecycleTransitions[state] {
+			if _, visited := previous[next]; !visited {
+				previous[next] = state
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// getLifecycleState returns the lifecycle state and the available transitions of the API
+func getLifecycleState(httpClient *resty.Client, accessToken, endpoint, apiId string) (*LifecycleStateResponse,
+	error) {
+	headers := make(map[string]string)
+	headers[HeaderAuthorization] = HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+
+	resp, err := invokeGETRequest(httpClient, endpoint+"/"+defaultApiListEndpointSuffix+"/"+apiId+"/"+
+		lifecycleStateEndpointSuffix, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, httpclient.NewStatusError("get lifecycle state of API", resp)
+	}
+
+	lcState := &LifecycleStateResponse{}
+	if err := json.Unmarshal(resp.Body(), lcState); err != nil {
+		return nil, err
+	}
+	return lcState, nil
+}
+
+// invokeLifecycleAction invokes the given lifecycle action (e.g. "Publish") on the API
+func invokeLifecycleAction(httpClient *resty.Client, accessToken, endpoint, apiId, action string) error {
+	headers := make(map[string]string)
+	headers[HeaderAuthorization] = HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+
+	resp, err := invokePOSTRequest(httpClient, endpoint+"/"+changeLifecycleEndpoint+"?apiId="+url.QueryEscape(apiId)+
+		"&action="+url.QueryEscape(action), headers, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return httpclient.NewStatusError("change lifecycle state of API", resp)
+	}
+	return nil
+}
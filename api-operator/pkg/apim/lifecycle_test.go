@@ -0,0 +1,41 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"reflect"
+	"testing"
+)
+
+func TestLifecyclePath(t *testing.T) {
+
+	path := lifecyclePath(wso2v1alpha2.LifecycleCreated, wso2v1alpha2.LifecycleRetired)
+	expected := []wso2v1alpha2.LifecycleState{wso2v1alpha2.LifecyclePublished, wso2v1alpha2.LifecycleDeprecated,
+		wso2v1alpha2.LifecycleRetired}
+	if !reflect.DeepEqual(path, expected) {
+		t.Errorf("expected the path %v to retire a created API but was %v", expected, path)
+	}
+
+	if path := lifecyclePath(wso2v1alpha2.LifecyclePublished, wso2v1alpha2.LifecyclePublished); len(path) != 0 {
+		t.Errorf("expected an empty path for the current state but was %v", path)
+	}
+
+	if path := lifecyclePath(wso2v1alpha2.LifecycleRetired, wso2v1alpha2.LifecyclePublished); path != nil {
+		t.Errorf("expected no path from the retired state but was %v", path)
+	}
+}
@@ -17,17 +17,15 @@
 package apim
 
 import (
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
 	"net/http"
-	"net/url"
 	"strings"
 
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"gopkg.in/resty.v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
@@ -35,7 +33,6 @@ import (
 
 var logLogin = log.Log.WithName("apim.login")
 var clientInfo = make(map[string]string)
-var certPool *x509.CertPool
 
 type ClientRegistrationResponse struct {
 	ClientID     string `json:"clientId"`
@@ -54,10 +51,10 @@ type TokenResponse struct {
 }
 
 // login registers a user with APIM to get clientId and clientSecret
-func login(client *client.Client, username string, password string, endpoint string) (cId string, cSecret string, error error) {
+func login(client *client.Client, httpClient *resty.Client, username string, password string, endpoint string) (cId string, cSecret string, error error) {
 
 	registrationEndpoint := endpoint + "/" + defaultClientRegistrationEndpointSuffix
-	clientId, clientSecret, clientErr := getClientIdSecret(username, password, registrationEndpoint)
+	clientId, clientSecret, clientErr := getClientIdSecret(httpClient, username, password, registrationEndpoint)
 	if clientErr != nil {
 		return "", "", clientErr
 	}
@@ -77,7 +74,7 @@ func login(client *client.Client, username string, password string, endpoint str
 }
 
 // getClientIdSecret returns clientId and clientSecret after registering with APIM
-func getClientIdSecret(username string, password string, registrationEndpoint string) (clientID string,
+func getClientIdSecret(httpClient *resty.Client, username string, password string, registrationEndpoint string) (clientID string,
 	clientSecret string, err error) {
 
 	requestBody := strings.TrimSpace(`{"callbackUrl": "www.wso2.com",
@@ -92,7 +89,7 @@ func getClientIdSecret(username string, password string, registrationEndpoint st
 	requestHeaders[HeaderAuthorization] = HeaderValueAuthBasicPrefix +
 		" " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
 
-	resp, err := invokePOSTRequest(registrationEndpoint, requestHeaders, requestBody)
+	resp, err := invokePOSTRequest(httpClient, registrationEndpoint, requestHeaders, requestBody)
 
 	if err != nil {
 		logLogin.Error(err, "Error getting client credentials")
@@ -109,90 +106,6 @@ func getClientIdSecret(username string, password string, registrationEndpoint st
 
 		return registrationResponse.ClientID, registrationResponse.ClientSecret, nil
 	} else {
-		if resp.StatusCode() == http.StatusUnauthorized {
-			// 401 Unauthorized
-			return "", "",
-				fmt.Errorf("authorization failed during client registration process")
-		}
-		return "", "", fmt.Errorf("Request didn't respond 200 OK: " + resp.Status())
-	}
-}
-
-// getAccessToken returns an access token to use REST APIs in APIM
-func getAccessToken(client *client.Client,
-	tokenEndpoint string, dcrEndpoint string, secretName string) (accessToken string, error error) {
-	var username, password, clientId, clientSecret string
-
-	apimSecret := k8s.NewSecret()
-	errToken := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: secretName}, apimSecret)
-	if errToken != nil {
-		return "", errToken
-	}
-	username = string(apimSecret.Data["username"])
-	password = string(apimSecret.Data["password"])
-	certSecret := string(apimSecret.Data["cert_security"])
-
-	if !insecure {
-		errToken = getCert(client, certSecret)
-		if errToken != nil {
-			return "", errToken
-		}
-	}
-
-	if len(clientInfo) != 0 {
-		logLogin.Info("Getting clientId and clientSecret from memory")
-		clientId = clientInfo[clientIdConst]
-		clientSecret = clientInfo[clientSecretConst]
-	} else {
-		ckcsSecret := k8s.NewSecret()
-		errToken = k8s.Get(client,
-			types.NamespacedName{Namespace: config.SystemNamespace, Name: clientRegistrationSecret}, ckcsSecret)
-		if errToken != nil {
-			if errors.IsNotFound(errToken) {
-				logLogin.Info("Client ID, Client Secret not found. Logging in...")
-				clientId, clientSecret, errToken = login(client, username, password, dcrEndpoint)
-				if errToken != nil {
-					return "", errToken
-				}
-			} else {
-				logLogin.Error(errToken, "Error retrieving CKCS secret")
-				return "", errToken
-			}
-		} else {
-			// On a restart, read the ckcs secret and update the in-memory values
-			logLogin.Info("Getting clientId and clientSecret from ckcs-secret and setting it to memory")
-			clientId = string(ckcsSecret.Data[clientIdConst])
-			clientSecret = string(ckcsSecret.Data[clientSecretConst])
-			clientInfo[clientIdConst] = clientId
-			clientInfo[clientSecretConst] = clientSecret
-		}
-	}
-
-	requestBody := "grant_type=password&username=" + username + "&password=" + url.QueryEscape(password) +
-		"&scope=apim:api_import_export+apim:api_view+apim:api_create+apim:api_delete+apim:api_publish"
-
-	requestHeaders := make(map[string]string)
-	requestHeaders[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
-	requestHeaders[HeaderAuthorization] = HeaderValueAuthBasicPrefix +
-		" " + base64.StdEncoding.EncodeToString([]byte(clientId+":"+clientSecret))
-	requestHeaders[HeaderAccept] = HeaderValueApplicationJSON
-
-	resp, err := invokePOSTRequest(tokenEndpoint, requestHeaders, requestBody)
-
-	if err != nil {
-		return "", err
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("Unable to get token. Status:" + resp.Status())
+		return "", "", httpclient.NewStatusError("register client", resp)
 	}
-
-	tokenResponse := TokenResponse{}
-	tokenErr := json.Unmarshal(resp.Body(), &tokenResponse)
-	if tokenErr != nil {
-		logLogin.Error(tokenErr, "Error in access token response")
-		return "", tokenErr
-	}
-
-	return tokenResponse.AccessToken, nil
 }
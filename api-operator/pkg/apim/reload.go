@@ -0,0 +1,74 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigObjectNames returns the names of the configmap and secrets in the system namespace that configure the
+// connection to APIM
+func ConfigObjectNames(client *client.Client) []string {
+	names := []string{apimConfName}
+	apimConfig, err := getRESTAPIConfigs(client)
+	if err != nil || apimConfig.CredentialsSecretName == "" {
+		return names
+	}
+	names = append(names, apimConfig.CredentialsSecretName)
+
+	apimSecret := k8s.NewSecret()
+	err = k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace,
+		Name: apimConfig.CredentialsSecretName}, apimSecret)
+	if err == nil && len(apimSecret.Data[certSecurityConst]) != 0 {
+		names = append(names, string(apimSecret.Data[certSecurityConst]))
+	}
+	return names
+}
+
+// InvalidateCaches discards the cached access tokens, client credentials and HTTP client of APIM, so that changes to
+// the APIM configs and credentials take effect
+func InvalidateCaches() {
+	tokenMux.Lock()
+	tokens = make(map[string]*accessToken)
+	// the client credentials are read again from the ckcs-secret, and a new client is registered if APIM rejects them
+	clientInfo = make(map[string]string)
+	tokenMux.Unlock()
+	httpclient.Invalidate(apimTarget)
+}
+
+// ReloadConfigs discards the cached configs of APIM with InvalidateCaches and validates the connectivity by
+// requesting an access token
+func ReloadConfigs(client *client.Client) error {
+	InvalidateCaches()
+
+	apimConfig, err := getRESTAPIConfigs(client)
+	if err != nil {
+		return err
+	}
+	httpClient, err := getAPIMClient(client, apimConfig)
+	if err != nil {
+		return err
+	}
+
+	_, err = getAccessToken(client, httpClient, getTokenEndpoint(apimConfig), apimConfig.KeyManagerEndpoint,
+		apimConfig.CredentialsSecretName)
+	return err
+}
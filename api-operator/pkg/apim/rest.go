@@ -17,61 +17,28 @@
 package apim
 
 import (
-	"crypto/tls"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"gopkg.in/resty.v1"
-	"time"
 )
 
-func invokePOSTRequest(url string, headers map[string]string, body interface{}) (*resty.Response, error) {
-	httpClient := resty.New()
-	if insecure {
-		httpClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-	} else {
-		httpClient.SetTLSClientConfig(&tls.Config{RootCAs: certPool, InsecureSkipVerify: false})
-	}
-
-	httpClient.SetTimeout(time.Duration(DefaultHttpRequestTimeout) * time.Millisecond)
-
-	response, err := httpClient.R().SetHeaders(headers).SetBody(body).Post(url)
-	return response, err
+func invokePOSTRequest(httpClient *resty.Client, url string, headers map[string]string, body interface{}) (
+	*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).SetBody(body).Post(url)
+	return resp, httpclient.NewRequestError("send POST request to "+url, err)
 }
 
-func invokeGETRequest(url string, headers map[string]string) (*resty.Response, error) {
-	httpClient := resty.New()
-	if insecure {
-		httpClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-	} else {
-		httpClient.SetTLSClientConfig(&tls.Config{RootCAs: certPool, InsecureSkipVerify: false})
-	}
-	httpClient.SetTimeout(time.Duration(DefaultHttpRequestTimeout) * time.Millisecond)
-
-	response, err := httpClient.R().SetHeaders(headers).Get(url)
-	return response, err
+func invokeGETRequest(httpClient *resty.Client, url string, headers map[string]string) (*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).Get(url)
+	return resp, httpclient.NewRequestError("send GET request to "+url, err)
 }
 
-func invokePUTRequest(url string, headers map[string]string, body interface{}) (*resty.Response, error) {
-	httpClient := resty.New()
-	if insecure {
-		httpClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-	} else {
-		httpClient.SetTLSClientConfig(&tls.Config{RootCAs: certPool, InsecureSkipVerify: false})
-	}
-	httpClient.SetTimeout(time.Duration(DefaultHttpRequestTimeout) * time.Millisecond)
-
-	response, err := httpClient.R().SetHeaders(headers).SetBody(body).Put(url)
-	return response, err
+func invokePUTRequest(httpClient *resty.Client, url string, headers map[string]string, body interface{}) (
+	*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).SetBody(body).Put(url)
+	return resp, httpclient.NewRequestError("send PUT request to "+url, err)
 }
 
-func invokeDELETERequest(url string, headers map[string]string) (*resty.Response, error) {
-	httpClient := resty.New()
-	if insecure {
-		httpClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-	} else {
-		httpClient.SetTLSClientConfig(&tls.Config{RootCAs: certPool, InsecureSkipVerify: false})
-	}
-	httpClient.SetTimeout(time.Duration(DefaultHttpRequestTimeout) * time.Millisecond)
-
-	response, err := httpClient.R().SetHeaders(headers).Delete(url)
-
-	return response, err
+func invokeDELETERequest(httpClient *resty.Client, url string, headers map[string]string) (*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).Delete(url)
+	return resp, httpclient.NewRequestError("send DELETE request to "+url, err)
 }
@@ -0,0 +1,114 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var logRevision = log.Log.WithName("apim.revision")
+
+const revisionDescription = "Deployed by the API Operator"
+
+// deployAPIRevision creates a new revision of the API and deploys it to the given gateway environments. The oldest
+// undeployed revision is deleted if the API has the maximum number of revisions. If all the revisions are deployed,
+// the oldest revision is undeployed from its environments and deleted.
+func deployAPIRevision(httpClient *resty.Client, accessToken, endpoint, apiId string,
+	envs []wso2v1alpha2.DeploymentEnvironment) error {
+	revisionsEndpoint := endpoint + "/" + defaultApiListEndpointSuffix + "/" + url.PathEscape(apiId) + "/" +
+		revisionsEndpointSuffix
+	headers := jsonRequestHeaders(accessToken)
+
+	resp, err := invokeGETRequest(httpClient, revisionsEndpoint, headers)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return httpclient.NewStatusError("get revisions of API", resp)
+	}
+	revisions := &APIRevisionListResponse{}
+	if err := json.Unmarshal(resp.Body(), revisions); err != nil {
+		return err
+	}
+	if len(revisions.List) >= maxAPIRevisions {
+		oldest := revisionToDelete(revisions.List)
+		if len(oldest.DeploymentInfo) != 0 {
+			logRevision.Info("Undeploying the oldest revision of the API as all the revisions are deployed",
+				"api_id", apiId, "revision_id", oldest.ID)
+			resp, err = invokePOSTRequest(httpClient, endpoint+"/"+defaultApiListEndpointSuffix+"/"+
+				url.PathEscape(apiId)+"/"+undeployRevisionEndpointSuffix+"?revisionId="+url.QueryEscape(oldest.ID),
+				headers, oldest.DeploymentInfo)
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+				return httpclient.NewStatusError("undeploy revision of API", resp)
+			}
+		}
+		logRevision.Info("Deleting the oldest revision of the API", "api_id", apiId, "revision_id", oldest.ID)
+		if err := deleteResource(httpClient, accessToken, revisionsEndpoint+"/"+url.PathEscape(oldest.ID),
+			"delete revision of API"); err != nil {
+			return err
+		}
+	}
+
+	resp, err = invokePOSTRequest(httpClient, revisionsEndpoint, headers, &APIRevision{Description: revisionDescription})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return httpclient.NewStatusError("create revision of API", resp)
+	}
+	revision := &APIRevision{}
+	if err := json.Unmarshal(resp.Body(), revision); err != nil {
+		return err
+	}
+
+	deployments := make([]APIRevisionDeployment, 0, len(envs))
+	for _, env := range envs {
+		deployments = append(deployments, APIRevisionDeployment{Name: env.Name,
+			DisplayOnDevportal: env.DisplayOnDevportal})
+	}
+	logRevision.Info("Deploying the revision of the API", "api_id", apiId, "revision_id", revision.ID,
+		"environments", envs)
+	resp, err = invokePOSTRequest(httpClient, endpoint+"/"+defaultApiListEndpointSuffix+"/"+url.PathEscape(apiId)+
+		"/"+deployRevisionEndpointSuffix+"?revisionId="+url.QueryEscape(revision.ID), headers, deployments)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return httpclient.NewStatusError("deploy revision of API", resp)
+	}
+	return nil
+}
+
+// revisionToDelete returns the first revision not deployed to any environment, or the first revision if all the
+// revisions are deployed. Revisions are listed by APIM in the order of creation.
+func revisionToDelete(revisions []APIRevision) APIRevision {
+	for _, revision := range revisions {
+		if len(revision.DeploymentInfo) == 0 {
+			return revision
+		}
+	}
+	return revisions[0]
+}
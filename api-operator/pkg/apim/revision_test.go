@@ -0,0 +1,94 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestDeployAPIRevisionWithAllRevisionsDeployed(t *testing.T) {
+	revisions := &APIRevisionListResponse{Count: maxAPIRevisions}
+	for i := 1; i <= maxAPIRevisions; i++ {
+		revisions.List = append(revisions.List, APIRevision{ID: fmt.Sprintf("rev-%d", i),
+			DeploymentInfo: []APIRevisionDeployment{{Name: fmt.Sprintf("env-%d", i)}}})
+	}
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(revisions)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/am/publisher/v2/apis/api-id/undeploy-revision":
+			var deployments []APIRevisionDeployment
+			if err := json.NewDecoder(r.Body).Decode(&deployments); err != nil ||
+				!reflect.DeepEqual(deployments, revisions.List[0].DeploymentInfo) {
+				t.Errorf("expected undeploying the environments of the oldest revision but was %+v, %v",
+					deployments, err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/am/publisher/v2/apis/api-id/revisions":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(&APIRevision{ID: "rev-6"})
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	err := deployAPIRevision(resty.New(), "token", server.URL, "api-id",
+		[]wso2v1alpha2.DeploymentEnvironment{{Name: "Default"}})
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	expected := []string{
+		"GET /api/am/publisher/v2/apis/api-id/revisions",
+		"POST /api/am/publisher/v2/apis/api-id/undeploy-revision?revisionId=rev-1",
+		"DELETE /api/am/publisher/v2/apis/api-id/revisions/rev-1",
+		"POST /api/am/publisher/v2/apis/api-id/revisions",
+		"POST /api/am/publisher/v2/apis/api-id/deploy-revision?revisionId=rev-6",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected the requests %v but was %v", expected, requests)
+	}
+}
+
+func TestRevisionToDelete(t *testing.T) {
+	revisions := []APIRevision{
+		{ID: "rev-1", DeploymentInfo: []APIRevisionDeployment{{Name: "Default"}}},
+		{ID: "rev-2"},
+		{ID: "rev-3"},
+	}
+	if revision := revisionToDelete(revisions); revision.ID != "rev-2" {
+		t.Errorf("expected the oldest undeployed revision but was %q", revision.ID)
+	}
+
+	revisions[1].DeploymentInfo = revisions[0].DeploymentInfo
+	revisions[2].DeploymentInfo = revisions[0].DeploymentInfo
+	if revision := revisionToDelete(revisions); revision.ID != "rev-1" {
+		t.Errorf("expected the oldest revision when all the revisions are deployed but was %q", revision.ID)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/json"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var logSubscription = log.Log.WithName("apim.subscription")
+
+// SyncSubscription subscribes the application to the API in APIM with the given throttling tier, or updates the
+// throttling tier of the existing subscription. Returns the subscription in APIM.
+func SyncSubscription(client *client.Client, apiId, appId, throttlingTier string) (*Subscription, error) {
+	if throttlingTier == "" {
+		throttlingTier = defaultThrottlingTier
+	}
+	subscription := &Subscription{ApplicationID: appId, APIID: apiId, ThrottlingPolicy: throttlingTier}
+
+	var synced *Subscription
+	err := withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		var err error
+		synced, err = applySubscription(httpClient, accessToken,
+			apimConfig.DevportalEndpoint+"/"+devportalSubscriptionsEndpoint, subscription)
+		return err
+	})
+	return synced, err
+}
+
+// DeleteSubscription deletes the subscription with the given ID from APIM. Deleting a subscription that does not
+// exist is not an error.
+func DeleteSubscription(client *client.Client, subscriptionId string) error {
+	return withAccessToken(client, func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error {
+		endpoint := apimConfig.DevportalEndpoint + "/" + devportalSubscriptionsEndpoint + "/" +
+			url.PathEscape(subscriptionId)
+		return deleteResource(httpClient, accessToken, endpoint, "delete subscription")
+	})
+}
+
+// applySubscription creates the subscription if the application is not subscribed to the API, otherwise updates the
+// throttling tier of the existing subscription
+func applySubscription(httpClient *resty.Client, accessToken, endpoint string, subscription *Subscription) (
+	*Subscription, error) {
+	headers := jsonRequestHeaders(accessToken)
+	resp, err := invokeGETRequest(httpClient, endpoint+"?apiId="+url.QueryEscape(subscription.APIID)+
+		"&applicationId="+url.QueryEscape(subscription.ApplicationID), headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, httpclient.NewStatusError("get subscriptions", resp)
+	}
+	subscriptionList := &SubscriptionListResponse{}
+	if err := json.Unmarshal(resp.Body(), subscriptionList); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range subscriptionList.List {
+		if existing.APIID != subscription.APIID || existing.ApplicationID != subscription.ApplicationID {
+			continue
+		}
+		if existing.ThrottlingPolicy == subscription.ThrottlingPolicy {
+			return &existing, nil
+		}
+		logSubscription.Info("Updating the throttling tier of the subscription", "id", existing.SubscriptionID,
+			"tier", subscription.ThrottlingPolicy)
+		subscription.SubscriptionID = existing.SubscriptionID
+		resp, err = invokePUTRequest(httpClient, endpoint+"/"+url.PathEscape(existing.SubscriptionID), headers,
+			subscription)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, httpclient.NewStatusError("update subscription", resp)
+		}
+		return unmarshalSubscription(resp)
+	}
+
+	logSubscription.Info("Subscribing the application to the API", "api_id", subscription.APIID,
+		"application_id", subscription.ApplicationID)
+	resp, err = invokePOSTRequest(httpClient, endpoint, headers, subscription)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, httpclient.NewStatusError("create subscription", resp)
+	}
+	return unmarshalSubscription(resp)
+}
+
+func unmarshalSubscription(resp *resty.Response) (*Subscription, error) {
+	subscription := &Subscription{}
+	if err := json.Unmarshal(resp.Body(), subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
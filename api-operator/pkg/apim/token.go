@@ -0,0 +1,225 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apim
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"gopkg.in/resty.v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
+	"time"
+)
+
+// accessToken is an access token issued by the token endpoint of APIM
+type accessToken struct {
+	token        string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+var (
+	// tokenMux serializes the requests to the token and client registration endpoints, so that concurrent
+	// reconciliations share a single token refresh
+	tokenMux sync.Mutex
+	// tokens are the cached access tokens keyed by the token endpoint and the username
+	tokens = make(map[string]*accessToken)
+)
+
+// getAccessToken returns an access token to use REST APIs in APIM. The token is cached until shortly before it
+// expires and then renewed with the refresh token grant, falling back to the password grant.
+func getAccessToken(client *client.Client, httpClient *resty.Client, tokenEndpoint string, dcrEndpoint string,
+	secretName string) (string, error) {
+	tokenMux.Lock()
+	defer tokenMux.Unlock()
+
+	apimSecret := k8s.NewSecret()
+	errSecret := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: secretName}, apimSecret)
+	if errSecret != nil {
+		return "", errSecret
+	}
+	username := string(apimSecret.Data["username"])
+	password := string(apimSecret.Data["password"])
+
+	key := tokenEndpoint + "/" + username
+	cached := tokens[key]
+	if cached != nil && time.Now().Add(tokenExpiryMargin).Before(cached.expiresAt) {
+		return cached.token, nil
+	}
+	delete(tokens, key)
+
+	clientId, clientSecret, err := getClientCredentials(client, httpClient, username, password, dcrEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if cached != nil && cached.refreshToken != "" {
+		logLogin.Info("Refreshing the access token")
+		refreshed, errRefresh := requestAccessToken(httpClient, tokenEndpoint, clientId, clientSecret,
+			"grant_type=refresh_token&refresh_token="+url.QueryEscape(cached.refreshToken))
+		if errRefresh == nil {
+			tokens[key] = refreshed
+			return refreshed.token, nil
+		}
+		logLogin.Info("Unable to refresh the access token, requesting a new access token", "error", errRefresh.Error())
+	}
+
+	passwordGrant := "grant_type=password&username=" + url.QueryEscape(username) + "&password=" +
+		url.QueryEscape(password) + "&scope=" + url.QueryEscape(tokenScopes)
+	token, err := requestAccessToken(httpClient, tokenEndpoint, clientId, clientSecret, passwordGrant)
+	if httpclient.HasStatus(err, http.StatusUnauthorized) {
+		// the registered client is not valid anymore (e.g. the DCR application is deleted in APIM)
+		logLogin.Info("Client credentials are rejected by the token endpoint, registering a new client")
+		if errInvalidate := invalidateClientCredentials(client); errInvalidate != nil {
+			return "", errInvalidate
+		}
+		clientId, clientSecret, err = getClientCredentials(client, httpClient, username, password, dcrEndpoint)
+		if err != nil {
+			return "", err
+		}
+		token, err = requestAccessToken(httpClient, tokenEndpoint, clientId, clientSecret, passwordGrant)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	tokens[key] = token
+	return token.token, nil
+}
+
+// invalidateAccessToken removes the given access token from the cache, so that a new access token is requested
+// next time. It is called when APIM rejects the access token before it expires (e.g. the token is revoked).
+func invalidateAccessToken(token string) {
+	tokenMux.Lock()
+	defer tokenMux.Unlock()
+
+	for key, cached := range tokens {
+		if cached.token == token {
+			delete(tokens, key)
+		}
+	}
+}
+
+// getClientCredentials returns the clientId and clientSecret from memory, the ckcs-secret or by registering a new
+// client with APIM
+func getClientCredentials(client *client.Client, httpClient *resty.Client, username string, password string,
+	dcrEndpoint string) (string, string, error) {
+	if len(clientInfo) != 0 {
+		logLogin.Info("Getting clientId and clientSecret from memory")
+		return clientInfo[clientIdConst], clientInfo[clientSecretConst], nil
+	}
+
+	ckcsSecret := k8s.NewSecret()
+	err := k8s.Get(client,
+		types.NamespacedName{Namespace: config.SystemNamespace, Name: clientRegistrationSecret}, ckcsSecret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logLogin.Info("Client ID, Client Secret not found. Logging in...")
+			return login(client, httpClient, username, password, dcrEndpoint)
+		}
+		logLogin.Error(err, "Error retrieving CKCS secret")
+		return "", "", err
+	}
+
+	// On a restart, read the ckcs secret and update the in-memory values
+	logLogin.Info("Getting clientId and clientSecret from ckcs-secret and setting it to memory")
+	clientInfo[clientIdConst] = string(ckcsSecret.Data[clientIdConst])
+	clientInfo[clientSecretConst] = string(ckcsSecret.Data[clientSecretConst])
+	return clientInfo[clientIdConst], clientInfo[clientSecretConst], nil
+}
+
+// invalidateClientCredentials removes the clientId and clientSecret from memory and the ckcs-secret
+func invalidateClientCredentials(client *client.Client) error {
+	delete(clientInfo, clientIdConst)
+	delete(clientInfo, clientSecretConst)
+	ckcsSecret := k8s.NewSecretWith(
+		types.NamespacedName{Namespace: config.SystemNamespace, Name: clientRegistrationSecret}, nil, nil, nil)
+	return k8s.DeleteIfExists(client, ckcsSecret)
+}
+
+// requestAccessToken requests an access token from the token endpoint with the given grant
+func requestAccessToken(httpClient *resty.Client, tokenEndpoint string, clientId string, clientSecret string,
+	grant string) (*accessToken, error) {
+	requestHeaders := make(map[string]string)
+	requestHeaders[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
+	requestHeaders[HeaderAuthorization] = HeaderValueAuthBasicPrefix +
+		" " + base64.StdEncoding.EncodeToString([]byte(clientId+":"+clientSecret))
+	requestHeaders[HeaderAccept] = HeaderValueApplicationJSON
+
+	resp, err := invokePOSTRequest(httpClient, tokenEndpoint, requestHeaders, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, httpclient.NewStatusError("get token", resp)
+	}
+
+	tokenResponse := TokenResponse{}
+	tokenErr := json.Unmarshal(resp.Body(), &tokenResponse)
+	if tokenErr != nil {
+		logLogin.Error(tokenErr, "Error in access token response")
+		return nil, tokenErr
+	}
+
+	return &accessToken{
+		token:        tokenResponse.AccessToken,
+		refreshToken: tokenResponse.RefreshToken,
+		expiresAt:    time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// withAccessToken invokes the given function with the HTTP client, the REST API configs and an access token of APIM.
+// The function is invoked again with a new access token if APIM rejects the access token.
+func withAccessToken(client *client.Client,
+	fn func(httpClient *resty.Client, apimConfig *RESTConfig, accessToken string) error) error {
+	apimConfig, err := getRESTAPIConfigs(client)
+	if err != nil {
+		return err
+	}
+	httpClient, err := getAPIMClient(client, apimConfig)
+	if err != nil {
+		return err
+	}
+
+	tokenEndpoint := getTokenEndpoint(apimConfig)
+	accessToken, err := getAccessToken(client, httpClient, tokenEndpoint, apimConfig.KeyManagerEndpoint,
+		apimConfig.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	err = fn(httpClient, apimConfig, accessToken)
+	if httpclient.HasStatus(err, http.StatusUnauthorized) {
+		logLogin.Info("Access token is rejected by APIM, retrying with a new access token")
+		invalidateAccessToken(accessToken)
+		accessToken, err = getAccessToken(client, httpClient, tokenEndpoint, apimConfig.KeyManagerEndpoint,
+			apimConfig.CredentialsSecretName)
+		if err != nil {
+			return err
+		}
+		err = fn(httpClient, apimConfig, accessToken)
+	}
+	return err
+}
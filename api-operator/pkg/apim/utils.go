@@ -17,26 +17,31 @@
 package apim
 
 import (
-	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/swagger"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/utils"
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"gopkg.in/resty.v1"
 	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // getRESTAPIConfigs returns the APIM configs for REST API invocation
@@ -58,6 +63,10 @@ func getRESTAPIConfigs(client *client.Client) (*RESTConfig, error) {
 	configs.KeyManagerEndpoint = apimConfig.Data[apimRegistrationEndpointConst]
 	configs.PublisherEndpoint = apimConfig.Data[apimPublisherEndpointConst]
 	configs.TokenEndpoint = apimConfig.Data[apimTokenEndpointConst]
+	configs.DevportalEndpoint = apimConfig.Data[apimDevportalEndpointConst]
+	if configs.DevportalEndpoint == "" {
+		configs.DevportalEndpoint = configs.PublisherEndpoint
+	}
 	configs.CredentialsSecretName = apimConfig.Data[apimCredentialsConst]
 	skipVerify, err := strconv.ParseBool(apimConfig.Data[skipVerifyConst])
 	if err != nil {
@@ -68,8 +77,16 @@ func getRESTAPIConfigs(client *client.Client) (*RESTConfig, error) {
 	return configs, nil
 }
 
+// getTokenEndpoint returns the token endpoint of APIM, which defaults to the token endpoint of the key manager
+func getTokenEndpoint(apimConfig *RESTConfig) string {
+	if strings.EqualFold(apimConfig.TokenEndpoint, "") {
+		return apimConfig.KeyManagerEndpoint + "/" + defaultTokenEndpoint
+	}
+	return apimConfig.TokenEndpoint
+}
+
 // deleteAPIById generates the request payload for deleting an API in APIM
-func deleteAPIById(url, apiId, token string) error {
+func deleteAPIById(httpClient *resty.Client, url, apiId, token string) error {
 	requestHeaders := make(map[string]string)
 	requestHeaders[HeaderAuthorization] = HeaderValueAuthBearerPrefix + " " + token
 	requestHeaders[HeaderAccept] = "*/*"
@@ -77,36 +94,52 @@ func deleteAPIById(url, apiId, token string) error {
 
 	deleteEndpoint := url + "/" + defaultApiListEndpointSuffix + "/" + apiId
 
-	resp, err := invokeDELETERequest(deleteEndpoint, requestHeaders)
+	resp, err := invokeDELETERequest(httpClient, deleteEndpoint, requestHeaders)
 	if err != nil {
 		return err
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Unable to update API. Status:" + resp.Status())
+		return httpclient.NewStatusError("delete API", resp)
 	}
 
 	return nil
 }
 
 // getCert gets the public cert of APIM instance when skip verification is false
-func getCert(client *client.Client, certConf string) error {
+func getCert(client *client.Client, certConf string) ([]byte, error) {
 	apimCert := k8s.NewSecret()
 	errCert := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: certConf}, apimCert)
 	if errCert != nil {
-		return errCert
+		return nil, errCert
 	}
 
 	certName, errCert := maps.OneKey(apimCert.Data)
 	if errCert != nil {
-		return errCert
+		return nil, errCert
 	}
-	cert := string(apimCert.Data[certName])
-	certData := []byte(cert)
-	certPool = x509.NewCertPool()
-	certPool.AppendCertsFromPEM(certData)
+	return apimCert.Data[certName], nil
+}
 
-	return nil
+// getAPIMClient returns the HTTP client of the APIM instance. The cert of the instance is read from the secret
+// referred in the credentials secret when skip verification is false
+func getAPIMClient(client *client.Client, apimConfig *RESTConfig) (*resty.Client, error) {
+	clientConfig := &httpclient.Config{
+		InsecureSkipVerify: apimConfig.SkipVerification,
+		Timeout:            time.Duration(DefaultHttpRequestTimeout) * time.Millisecond,
+	}
+	if !apimConfig.SkipVerification {
+		apimSecret := k8s.NewSecret()
+		err := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace,
+			Name: apimConfig.CredentialsSecretName}, apimSecret)
+		if err != nil {
+			return nil, err
+		}
+		if clientConfig.CACerts, err = getCert(client, string(apimSecret.Data[certSecurityConst])); err != nil {
+			return nil, err
+		}
+	}
+	return httpclient.Get(apimTarget, clientConfig)
 }
 
 // GetAPIDefinition scans filePath and returns v2.APIDefinitionFile or an error
@@ -198,10 +231,47 @@ func GetAdditionalProperties(swaggerData string) (string, string, string, error)
 	return dataString, name, version, nil
 }
 
+// GetAPIInfo returns the name, version and base path (as the context) of the API defined in the given
+// swagger or project zip configmap
+func GetAPIInfo(config *corev1.ConfigMap) (*API, error) {
+	if config.BinaryData != nil {
+		zipFileName, err := maps.OneKey(config.BinaryData)
+		if err != nil {
+			return nil, err
+		}
+		tmpPath, err := GetTempPathOfExtractedArchive(config.BinaryData[zipFileName])
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(filepath.Dir(tmpPath))
+
+		apiInfo, err := GetAPIDefinition(tmpPath)
+		if err != nil {
+			return nil, err
+		}
+		return &API{Name: apiInfo.Data.Name, Version: apiInfo.Data.Version, Context: apiInfo.Data.Context}, nil
+	}
+
+	swaggerFileName, err := maps.OneKey(config.Data)
+	if err != nil {
+		return nil, err
+	}
+	swaggerData := config.Data[swaggerFileName]
+	swaggerDoc, err := swagger.GetSwaggerV3(&swaggerData)
+	if err != nil {
+		return nil, err
+	}
+	return &API{
+		Name:    strings.ReplaceAll(swaggerDoc.Info.Title, " ", ""),
+		Version: swaggerDoc.Info.Version,
+		Context: swagger.ApiBasePath(swaggerDoc),
+	}, nil
+}
+
 // getAPIUpdate returns API Id if an API exists in APIM with the specified name and version
-func getAPIId(accessToken, endpoint, name, version string) (string, error) {
+func getAPIId(httpClient *resty.Client, accessToken, endpoint, name, version string) (string, error) {
 	apiQuery := fmt.Sprintf("name:\"%s\" version:\"%s\"", name, version)
-	count, apis, err := getAPIList(accessToken, endpoint, apiQuery, "")
+	count, apis, err := getAPIList(httpClient, accessToken, endpoint, apiQuery, "")
 	if err != nil {
 		return "", err
 	}
@@ -212,7 +282,8 @@ func getAPIId(accessToken, endpoint, name, version string) (string, error) {
 }
 
 // getAPIList returns list of APIs from APIM matching the given query
-func getAPIList(accessToken, apiListEndpoint, query, limit string) (count int32, apis []API, err error) {
+func getAPIList(httpClient *resty.Client, accessToken, apiListEndpoint, query, limit string) (count int32,
+	apis []API, err error) {
 	queryParamAdded := false
 	getQueryParamConnector := func() (connector string) {
 		if queryParamAdded {
@@ -233,7 +304,7 @@ func getAPIList(accessToken, apiListEndpoint, query, limit string) (count int32,
 		apiListEndpoint += getQueryParamConnector() + "limit=" + url.QueryEscape(limit)
 	}
 
-	resp, err := invokeGETRequest(apiListEndpoint, headers)
+	resp, err := invokeGETRequest(httpClient, apiListEndpoint, headers)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -247,7 +318,7 @@ func getAPIList(accessToken, apiListEndpoint, query, limit string) (count int32,
 
 		return apiListResponse.Count, apiListResponse.List, nil
 	} else {
-		return 0, nil, fmt.Errorf("Unable to GET APIs. Status:" + resp.Status())
+		return 0, nil, httpclient.NewStatusError("get APIs", resp)
 	}
 }
 
@@ -302,3 +373,29 @@ func getTempFilesForSwagger(swagger, data string) (*os.File, *os.File, error) {
 func YamlToJson(yamlData []byte) ([]byte, error) {
 	return yaml.YAMLToJSON(yamlData)
 }
+
+// overrideZipDeploymentEnvironments returns the given project zip with the deployment environments replaced with the
+// given gateway environments
+func overrideZipDeploymentEnvironments(zippedData []byte, envs []wso2v1alpha2.DeploymentEnvironment) ([]byte, error) {
+	zipFile, err := ioutil.TempFile("", "api-binary.*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(zipFile.Name())
+	_, err = zipFile.Write(zippedData)
+	if closeErr := zipFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	overriddenZipFile, cleanupFunc, err := utils.OverrideDeploymentEnvironments(zipFile.Name(), envs)
+	if err != nil {
+		return nil, err
+	}
+	if cleanupFunc != nil {
+		defer cleanupFunc()
+	}
+	return ioutil.ReadFile(overriddenZipFile)
+}
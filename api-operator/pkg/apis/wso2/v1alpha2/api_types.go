@@ -17,6 +17,7 @@
 package v1alpha2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,6 +33,7 @@ type APISpec struct {
 	Mode Mode `json:"mode,omitempty"`
 	// Update API definition creating a new docker image. Make a rolling update to the existing API.
 	// with prefixing the timestamp value.
+	// Deprecated: Changes of the API definition are detected with the digest of the swagger, params and certs.
 	// Default value "<empty>".
 	// +optional
 	UpdateTimeStamp string `json:"updateTimeStamp,omitempty"`
@@ -67,10 +69,42 @@ type APISpec struct {
 	// Default value "<empty>".
 	// +optional
 	ParamsValues string `json:"paramsValues,omitempty"`
+	// Name of the environment in the params values to be used. Default value is the "paramsEnvironment" in the
+	// "api-controller-config" configmap, or the first environment in the params values if it is not configured.
+	// Default value "<empty>".
+	// +optional
+	ParamsEnvironment string `json:"paramsEnvironment,omitempty"`
+	// Security of the backend endpoints of the API with the credentials in a secret. Overrides the endpoint security
+	// in the params values.
+	// Default value "<empty>".
+	// +optional
+	EndpointSecurity *EndpointSecurity `json:"endpointSecurity,omitempty"`
 	// Config map name of the certs values of the API project
 	// Default value "<empty>".
 	// +optional
 	CertsValues string `json:"certsValues,omitempty"`
+	// Lifecycle state of the API in API Manager. The operator changes the lifecycle state of the imported API to
+	// reach this state. The lifecycle state is not managed if empty.
+	// Supports "Created", "Prototyped", "Published", "Blocked", "Deprecated", "Retired".
+	// Default value "<empty>".
+	// +kubebuilder:validation:Enum=Created;Prototyped;Published;Blocked;Deprecated;Retired
+	// +optional
+	LifecycleState LifecycleState `json:"lifecycleState,omitempty"`
+	// Gateway environments to deploy the API. If empty, the environments in the project zip are used and an API
+	// defined with a swagger is deployed to the "Default" environment of the Microgateway Adapter.
+	// Default value "<empty>".
+	// +optional
+	DeploymentEnvironments []DeploymentEnvironment `json:"deploymentEnvironments,omitempty"`
+}
+
+// DeploymentEnvironment is a gateway environment to deploy an API
+type DeploymentEnvironment struct {
+	// Name of the gateway environment.
+	Name string `json:"name"`
+	// Show the gateway environment of the API in the Developer Portal.
+	// Default value "false".
+	// +optional
+	DisplayOnDevportal bool `json:"displayOnDevportal,omitempty"`
 }
 
 // APIStatus defines the observed state of API
@@ -79,18 +113,86 @@ type APIStatus struct {
 	// Default value "<empty>".
 	// +optional
 	Replicas int `json:"replicas,omitempty"`
+	// The generation of the API observed by the API controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Latest available observations of the API for each deployment target.
+	// +optional
+	Conditions []APICondition `json:"conditions,omitempty"`
+	// ID of the API in API Manager.
+	// +optional
+	APIMID string `json:"apimId,omitempty"`
+	// Name of the API resolved from the swagger definition or the project zip.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Version of the API resolved from the swagger definition or the project zip.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Base path of the API resolved from the swagger definition or the project zip.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+	// Digest of the swagger definition or project zip, params and certs of the API last deployed.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Error message of the last failed reconciliation. Empty if the last reconciliation succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// Current lifecycle state of the API in API Manager.
+	// +optional
+	LifecycleState LifecycleState `json:"lifecycleState,omitempty"`
+	// TargetEndpoints referred by the endpoints in the swagger definition of the API as "<namespace>/<name>".
+	// +optional
+	TargetEndpoints []string `json:"targetEndpoints,omitempty"`
+	// EndpointGroups referred by the endpoints in the swagger definition of the API as "<namespace>/<name>".
+	// +optional
+	EndpointGroups []string `json:"endpointGroups,omitempty"`
+}
+
+// APIConditionType is a valid value for APICondition.Type
+type APIConditionType string
+
+const (
+	// APIReady means the API is deployed to all the enabled targets
+	APIReady APIConditionType = "Ready"
+	// APIImportedToAPIM means the API is imported to API Manager
+	APIImportedToAPIM APIConditionType = "ImportedToAPIM"
+	// APIDeployedToMicrogateway means the API is deployed to the Envoy Microgateway Adapter
+	APIDeployedToMicrogateway APIConditionType = "DeployedToMicrogateway"
+	// APILifecycleStateReached means the lifecycle state of the API in API Manager is the state in the spec
+	APILifecycleStateReached APIConditionType = "LifecycleStateReached"
+	// APIBackendAvailable means the TargetEndpoints referred by the API have available replicas
+	APIBackendAvailable APIConditionType = "BackendAvailable"
+)
+
+// APICondition describes the state of an API at a certain point
+type APICondition struct {
+	// Type of the API condition.
+	Type APIConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// The last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // API is the Schema for the apis API
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type API struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec   APISpec   `json:"spec,omitempty"`
+	Status APIStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -115,6 +217,66 @@ func (c Mode) String() string {
 	return string(c)
 }
 
+// LifecycleState is a lifecycle state of an API in API Manager
+type LifecycleState string
+
+const (
+	LifecycleCreated    LifecycleState = "Created"
+	LifecyclePrototyped LifecycleState = "Prototyped"
+	LifecyclePublished  LifecycleState = "Published"
+	LifecycleBlocked    LifecycleState = "Blocked"
+	LifecycleDeprecated LifecycleState = "Deprecated"
+	LifecycleRetired    LifecycleState = "Retired"
+)
+
+// GetCondition returns the condition of the given type or nil if the condition is not set
+func (s *APIStatus) GetCondition(condType APIConditionType) *APICondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates the condition of the given type. The transition time is updated only if the
+// status of the condition is changed.
+func (s *APIStatus) SetCondition(condType APIConditionType, status corev1.ConditionStatus, reason, message string) {
+	if cond := s.GetCondition(condType); cond != nil {
+		if cond.Status != status {
+			cond.Status = status
+			cond.LastTransitionTime = metav1.Now()
+		}
+		cond.Reason = reason
+		cond.Message = message
+		return
+	}
+	s.Conditions = append(s.Conditions, APICondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// RemoveCondition removes the condition of the given type
+func (s *APIStatus) RemoveCondition(condType APIConditionType) {
+	conditions := make([]APICondition, 0, len(s.Conditions))
+	for _, cond := range s.Conditions {
+		if cond.Type != condType {
+			conditions = append(conditions, cond)
+		}
+	}
+	s.Conditions = conditions
+}
+
+// IsConditionTrue returns true if the condition of the given type is set and its status is True
+func (s *APIStatus) IsConditionTrue(condType APIConditionType) bool {
+	cond := s.GetCondition(condType)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
 func init() {
 	SchemeBuilder.Register(&API{}, &APIList{})
 }
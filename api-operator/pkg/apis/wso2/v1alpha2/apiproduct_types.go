@@ -0,0 +1,115 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// APIProductSpec defines the desired state of APIProduct
+type APIProductSpec struct {
+	// Name of the API product in API Manager.
+	// Default value "<namespace>-<name>" of the APIProduct resource.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Context of the API product.
+	Context string `json:"context"`
+	// Version of the API product.
+	// Default value "1.0.0".
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Description of the API product.
+	// Default value "<empty>".
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Throttling tiers of the API product.
+	// Default value ["Unlimited"].
+	// +optional
+	Tiers []string `json:"tiers,omitempty"`
+	// APIs bundled in the API product.
+	// +kubebuilder:validation:MinItems=1
+	APIs []APIProductAPI `json:"apis"`
+}
+
+// APIProductAPI refers an API and its resources bundled in an API product
+type APIProductAPI struct {
+	// Name of the API resource. The API should be in the namespace of the APIProduct.
+	Name string `json:"name"`
+	// Resources of the API to be exposed by the API product. All the resources of the API are exposed if empty.
+	// Default value "<empty>".
+	// +optional
+	Resources []APIProductResource `json:"resources,omitempty"`
+}
+
+// APIProductResource is a resource of an API exposed by an API product
+type APIProductResource struct {
+	// Path of the resource in the API, e.g. "/pets/{petId}".
+	Path string `json:"path"`
+	// HTTP verb of the resource.
+	// +kubebuilder:validation:Enum=GET;POST;PUT;DELETE;PATCH;HEAD;OPTIONS
+	Verb string `json:"verb"`
+}
+
+// APIProductStatus defines the observed state of APIProduct
+type APIProductStatus struct {
+	// The generation of the APIProduct observed by the APIProduct controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ID of the API product in API Manager.
+	// +optional
+	APIMID string `json:"apimId,omitempty"`
+	// IDs in API Manager of the referred APIs by their names, the API product was last synced with.
+	// +optional
+	APIIDs map[string]string `json:"apiIds,omitempty"`
+	// Names of the referred API resources that are not found.
+	// +optional
+	MissingAPIs []string `json:"missingApis,omitempty"`
+	// Names of the referred API resources that are not imported to API Manager yet.
+	// +optional
+	UnimportedAPIs []string `json:"unimportedApis,omitempty"`
+	// Error message of the last failed reconciliation. Empty if the last reconciliation succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIProduct is the Schema for the apiproducts API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Context",type=string,JSONPath=`.spec.context`
+// +kubebuilder:printcolumn:name="APIM ID",type=string,JSONPath=`.status.apimId`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type APIProduct struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIProductSpec   `json:"spec,omitempty"`
+	Status APIProductStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIProductList contains a list of APIProduct
+type APIProductList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIProduct `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIProduct{}, &APIProductList{})
+}
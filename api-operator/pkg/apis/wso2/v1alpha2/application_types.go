@@ -0,0 +1,109 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationSpec defines the desired state of Application
+type ApplicationSpec struct {
+	// Name of the application in API Manager.
+	// Default value "<namespace>-<name>" of the Application resource.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Description of the application.
+	// Default value "<empty>".
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Throttling tier of the application.
+	// Default value "Unlimited".
+	// +optional
+	ThrottlingTier string `json:"throttlingTier,omitempty"`
+	// Type of the access tokens issued for the application. Supports "JWT", "OAUTH".
+	// Default value "JWT".
+	// +kubebuilder:validation:Enum=JWT;OAUTH
+	// +optional
+	TokenType string `json:"tokenType,omitempty"`
+	// Attributes of the application.
+	// Default value "<empty>".
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Keys of the application to be generated.
+	// +optional
+	Keys ApplicationKeys `json:"keys,omitempty"`
+}
+
+// ApplicationKeys defines the consumer key and secret to be generated for an Application
+type ApplicationKeys struct {
+	// Type of the keys. Supports "PRODUCTION", "SANDBOX".
+	// Default value "PRODUCTION".
+	// +kubebuilder:validation:Enum=PRODUCTION;SANDBOX
+	// +optional
+	KeyType string `json:"keyType,omitempty"`
+	// Grant types supported by the keys.
+	// Default value ["client_credentials"].
+	// +optional
+	GrantTypes []string `json:"grantTypes,omitempty"`
+	// Name of the secret to write the consumer key and secret. The secret is owned by the Application.
+	// Default value "<application-name>-keys".
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// ApplicationStatus defines the observed state of Application
+type ApplicationStatus struct {
+	// The generation of the Application observed by the Application controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ID of the application in API Manager.
+	// +optional
+	ApplicationID string `json:"applicationId,omitempty"`
+	// Name of the secret with the consumer key and secret of the application.
+	// +optional
+	KeySecretName string `json:"keySecretName,omitempty"`
+	// Error message of the last failed reconciliation. Empty if the last reconciliation succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Application is the Schema for the applications API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Application ID",type=string,JSONPath=`.status.applicationId`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationSpec   `json:"spec,omitempty"`
+	Status ApplicationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApplicationList contains a list of Application
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Application `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Application{}, &ApplicationList{})
+}
@@ -0,0 +1,104 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EndpointGroupType is a valid value for EndpointGroupSpec.Type
+type EndpointGroupType string
+
+const (
+	// LoadBalanceEndpointGroup distributes the requests among the members of the group
+	LoadBalanceEndpointGroup EndpointGroupType = "loadbalance"
+	// FailoverEndpointGroup sends the requests to the first member of the group and to the next members in order
+	// when the previous members fail
+	FailoverEndpointGroup EndpointGroupType = "failover"
+)
+
+// LoadBalanceAlgorithm is a valid value for EndpointGroupSpec.Algorithm
+type LoadBalanceAlgorithm string
+
+const (
+	// RoundRobin distributes the requests among the members of the group equally
+	RoundRobin LoadBalanceAlgorithm = "roundRobin"
+	// Weighted distributes the requests among the members of the group by the weights of the members
+	Weighted LoadBalanceAlgorithm = "weighted"
+)
+
+// EndpointGroupSpec defines the desired state of EndpointGroup
+type EndpointGroupSpec struct {
+	// Type of the endpoint group. Supports "loadbalance", "failover".
+	// +kubebuilder:validation:Enum=loadbalance;failover
+	Type EndpointGroupType `json:"type"`
+	// Load balancing algorithm of a "loadbalance" endpoint group. Supports "roundRobin", "weighted".
+	// Default value "roundRobin".
+	// +kubebuilder:validation:Enum=roundRobin;weighted
+	// +optional
+	Algorithm LoadBalanceAlgorithm `json:"algorithm,omitempty"`
+	// Members of the endpoint group. The first member of a "failover" endpoint group is the primary endpoint and
+	// the rest are the failover endpoints in order.
+	// +kubebuilder:validation:MinItems=1
+	Members []EndpointGroupMember `json:"members"`
+}
+
+// EndpointGroupMember is a TargetEndpoint or an external URL in an endpoint group
+type EndpointGroupMember struct {
+	// Name of a TargetEndpoint in the namespace of the EndpointGroup. Either the TargetEndpoint or the URL should
+	// be specified.
+	// +optional
+	TargetEndpoint string `json:"targetEndpoint,omitempty"`
+	// Port of the TargetEndpoint.
+	// Default value is the first port of the TargetEndpoint.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// URL of an external endpoint.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Weight of the member in a "weighted" load balancing endpoint group.
+	// Default value "1".
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EndpointGroup is the Schema for the endpointgroups API
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type EndpointGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EndpointGroupSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EndpointGroupList contains a list of EndpointGroup
+type EndpointGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EndpointGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EndpointGroup{}, &EndpointGroupList{})
+}
@@ -0,0 +1,85 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SubscriptionSpec defines the desired state of Subscription
+type SubscriptionSpec struct {
+	// Name of the API resource to subscribe. The API should be in the namespace of the Subscription.
+	APIName string `json:"apiName"`
+	// Name of the Application resource subscribing to the API. The Application should be in the namespace of the
+	// Subscription.
+	ApplicationName string `json:"applicationName"`
+	// Throttling tier of the subscription.
+	// Default value "Unlimited".
+	// +optional
+	ThrottlingTier string `json:"throttlingTier,omitempty"`
+}
+
+// SubscriptionStatus defines the observed state of Subscription
+type SubscriptionStatus struct {
+	// The generation of the Subscription observed by the Subscription controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ID of the subscription in API Manager.
+	// +optional
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+	// ID of the subscribed API in API Manager.
+	// +optional
+	APIMID string `json:"apimId,omitempty"`
+	// ID of the subscribed application in API Manager.
+	// +optional
+	ApplicationID string `json:"applicationId,omitempty"`
+	// Status of the subscription in API Manager, e.g. "UNBLOCKED", "BLOCKED", "ON_HOLD".
+	// +optional
+	Status string `json:"status,omitempty"`
+	// Error message of the last failed reconciliation. Empty if the last reconciliation succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Subscription is the Schema for the subscriptions API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="API",type=string,JSONPath=`.spec.apiName`
+// +kubebuilder:printcolumn:name="Application",type=string,JSONPath=`.spec.applicationName`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubscriptionSpec   `json:"spec,omitempty"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SubscriptionList contains a list of Subscription
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subscription `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Subscription{}, &SubscriptionList{})
+}
@@ -37,20 +37,39 @@ type TargetEndpointSpec struct {
 	// Default value "privateJet"
 	// +optional
 	Mode Mode `json:"mode,omitempty"`
+	// Security of the endpoint with the credentials in a secret. Used by the APIs in the namespace of the
+	// TargetEndpoint which refer it in their endpoints and do not define an endpoint security.
+	// Default value "<empty>".
+	// +optional
+	EndpointSecurity *EndpointSecurity `json:"endpointSecurity,omitempty"`
 }
 
 // TargetEndpointStatus defines the observed state of TargetEndpoint
 // +k8s:openapi-gen=true
 type TargetEndpointStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
-	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	// Number of available replicas of the deployment of the TargetEndpoint in "privateJet" mode.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// The generation of the TargetEndpoint observed by the TargetEndpoint controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// EndpointSecurity is the security of backend endpoints with the credentials in a secret
 type EndpointSecurity struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Type     string `json:"type"`
+	// Type of the endpoint security. Supports "basic", "digest".
+	// +kubebuilder:validation:Enum=basic;digest
+	Type string `json:"type"`
+	// Name of the secret with the credentials. The secret should be in the namespace of the resource.
+	SecretName string `json:"secretName"`
+	// Key of the username in the secret.
+	// Default value "username".
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+	// Key of the password in the secret.
+	// Default value "password".
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
 }
 
 // Port represents ports of the Target Endpoint
@@ -80,6 +99,7 @@ type Deploy struct {
 
 // TargetEndpoint is the Schema for the targetendpoints API
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 type TargetEndpoint struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,44 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const DefaultBundleDebounceWindow = 2 * time.Second
+const BundleDebounceWindowEnv = "BUNDLE_DEBOUNCE_WINDOW"
+
+// BundleDebounceWindow is the duration to wait for more API changes before rebuilding the API bundle
+var BundleDebounceWindow = DefaultBundleDebounceWindow
+
+// SetBundleDebounceWindowFromEnv sets the debounce window of the API bundle from the environment, i.e. "500ms", "2s"
+func SetBundleDebounceWindowFromEnv() (found bool, err error) {
+	value, found := os.LookupEnv(BundleDebounceWindowEnv)
+	if !found {
+		BundleDebounceWindow = DefaultBundleDebounceWindow
+		return
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return
+	}
+	BundleDebounceWindow = window
+	return
+}
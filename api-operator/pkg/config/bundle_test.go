@@ -0,0 +1,44 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetBundleDebounceWindowFromEnv(t *testing.T) {
+
+	found, err := SetBundleDebounceWindowFromEnv()
+	if found || err != nil || BundleDebounceWindow != DefaultBundleDebounceWindow {
+		t.Error("expected the default debounce window as the env has not been set.")
+	}
+
+	os.Setenv(BundleDebounceWindowEnv, "500ms")
+	found, err = SetBundleDebounceWindowFromEnv()
+	if !found || err != nil || BundleDebounceWindow != 500*time.Millisecond {
+		t.Errorf("expected the debounce window 500ms but was %v", BundleDebounceWindow)
+	}
+
+	os.Setenv(BundleDebounceWindowEnv, "invalid")
+	if _, err = SetBundleDebounceWindowFromEnv(); err == nil {
+		t.Error("expected an error as the debounce window is invalid.")
+	}
+	os.Unsetenv(BundleDebounceWindowEnv)
+}
@@ -21,13 +21,17 @@ import (
 	"fmt"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/digest"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/endpoints"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"strconv"
 	"time"
 
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -47,15 +52,22 @@ var log = logf.Log.WithName("api.controller")
 // Add creates a new API Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	r := newReconciler(mgr)
+	// Build the initial bundle of APIs once the manager is started
+	if err := mgr.Add(r.bundleBuilder); err != nil {
+		return err
+	}
+	return add(mgr, r)
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager) *ReconcileAPI {
 	return &ReconcileAPI{
 		client:   mgr.GetClient(),
 		scheme:   mgr.GetScheme(),
 		recorder: mgr.GetEventRecorderFor("api-controller"),
+		bundleBuilder: envoy.NewBundleBuilder(mgr.GetClient(), common.WatchNamespace,
+			config.BundleDebounceWindow),
 	}
 }
 
@@ -80,6 +92,59 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Index APIs by the swagger, params and certs configmaps referred by them
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.API{}, configMapIndexKey,
+		apiConfigMapIndexer)
+	if err != nil {
+		return err
+	}
+
+	// Index APIs by the target endpoints referred by them
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.API{}, targetEndpointIndexKey,
+		apiTargetEndpointIndexer)
+	if err != nil {
+		return err
+	}
+
+	// Index APIs by the endpoint groups referred by them
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.API{}, endpointGroupIndexKey,
+		apiEndpointGroupIndexer)
+	if err != nil {
+		return err
+	}
+
+	// Index endpoint groups by the target endpoints in their members
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.EndpointGroup{}, memberIndexKey,
+		endpointGroupMemberIndexer)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to target endpoints and their availability and requeue the APIs referring them directly or
+	// through endpoint groups
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.TargetEndpoint{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &targetEndpointToAPIsMapper{client: mgr.GetClient()}},
+		targetEndpointChangedPredicate)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to endpoint groups and requeue the APIs referring them
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.EndpointGroup{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &endpointGroupToAPIsMapper{client: mgr.GetClient()}},
+		predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to configmaps and requeue the APIs referring them
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &configMapToAPIsMapper{client: mgr.GetClient()}},
+		configMapDataChangedPredicate)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -89,9 +154,10 @@ var _ reconcile.Reconciler = &ReconcileAPI{}
 type ReconcileAPI struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	client   client.Client
-	scheme   *runtime.Scheme
-	recorder record.EventRecorder
+	client        client.Client
+	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
+	bundleBuilder *envoy.BundleBuilder
 }
 
 // Reconcile reads that state of the cluster for a API object and makes changes based on the state read
@@ -109,13 +175,8 @@ func (r *ReconcileAPI) Reconcile(request reconcile.Request) (reconcile.Result, e
 	requestInfo := &common.RequestInfo{Request: request, Client: r.client, Object: instance, Log: log, EvnRecorder: r.recorder}
 	ctx = requestInfo.NewContext(ctx)
 
-	apiList := &wso2v1alpha2.APIList{}
-	if err := requestInfo.Client.List(ctx, apiList, client.InNamespace(common.WatchNamespace)); err != nil {
-		// Error reading the object - requeue the request.
-		reqLogger.Error(err, "Error reading all APIs in the specified namespace", "namespace",
-			common.WatchNamespace)
-		return reconcile.Result{}, err
-	}
+	// Request to update the snapshot of the APIs in k8s cluster to be served to MGW Adapter
+	r.bundleBuilder.RequestUpdate()
 
 	err := k8s.Get(&r.client, request.NamespacedName, instance)
 	if err != nil {
@@ -128,34 +189,64 @@ func (r *ReconcileAPI) Reconcile(request reconcile.Request) (reconcile.Result, e
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
-	err = envoy.CreateFileToSend(apiList, &r.client)
-	if err != nil {
-		reqLogger.Error(err, "Error when sending all the APIs to MGW Adapter")
-	}
-
 
 	// Handle deletion with finalizers
 	if _, finUpdated, err := k8s.HandleDeletion(instance, ctx, requestInfo, finalizerName, r.finalizeDeletion); finUpdated || err != nil {
 		// If finalizer updated, end the flow as a new request will queue
 		// If error should requeue request
-		return reconcile.Result{}, err
+		if err != nil {
+			return r.handleDeletionError(ctx, instance, err)
+		}
+		return reconcile.Result{}, nil
 	}
 
-	if err := requestInfo.Client.List(ctx, apiList, client.InNamespace(common.WatchNamespace)); err != nil {
-		// Error reading the object - requeue the request.
-		reqLogger.Error(err, "Error reading all APIs in the specified namespace", "namespace",
-			common.WatchNamespace)
-		return reconcile.Result{}, err
-	}
-	// Create the zip file with APIs in k8s cluster to be sent to MGW Adapter
-	err = envoy.CreateFileToSend(apiList, &r.client)
+	oldStatus := instance.Status.DeepCopy()
+	instance.Status.LastError = ""
+	result, err := r.deployAPI(instance)
 	if err != nil {
-		reqLogger.Error(err, "Error when sending all the APIs to MGW Adapter")
+		instance.Status.LastError = err.Error()
+	}
+	if errStatus := r.updateStatus(ctx, instance, oldStatus); errStatus != nil {
+		reqLogger.Error(errStatus, "Error updating the status of the API")
+		if err == nil {
+			return reconcile.Result{}, errStatus
+		}
 	}
+	if httpclient.IsPermanent(err) {
+		// retrying immediately does not resolve a permanent error, the error is surfaced in the status instead
+		reqLogger.Error(err, "Permanent error while deploying the API, requeue after a delay",
+			"requeue_after", common.RequeueDurationForPermanentError)
+		return reconcile.Result{RequeueAfter: common.RequeueDurationForPermanentError}, nil
+	}
+	return result, err
+}
+
+// handleDeletionError surfaces the error of deleting the API from the deployment targets in the status. The API
+// is not deleted until the error is resolved, a permanent error is retried after a delay.
+func (r *ReconcileAPI) handleDeletionError(ctx context.Context, instance *wso2v1alpha2.API, err error) (
+	reconcile.Result, error) {
+	reqLogger := log.WithValues("request_namespace", instance.Namespace, "request_name", instance.Name)
+	oldStatus := instance.Status.DeepCopy()
+	instance.Status.LastError = err.Error()
+	if errStatus := r.updateStatus(ctx, instance, oldStatus); errStatus != nil {
+		reqLogger.Error(errStatus, "Error updating the status of the API")
+	}
+	if httpclient.IsPermanent(err) {
+		reqLogger.Error(err, "Permanent error while deleting the API, requeue after a delay",
+			"requeue_after", common.RequeueDurationForPermanentError)
+		return reconcile.Result{RequeueAfter: common.RequeueDurationForPermanentError}, nil
+	}
+	return reconcile.Result{}, err
+}
+
+// deployAPI imports the API to APIM and deploys the API to MGW Adapter based on the controller configurations and
+// sets the conditions of the status of the API
+func (r *ReconcileAPI) deployAPI(instance *wso2v1alpha2.API) (reconcile.Result, error) {
+	reqLogger := log.WithValues("request_namespace", instance.Namespace, "request_name", instance.Name)
 
 	//get configurations file for the controller
 	controlConf := k8s.NewConfMap()
-	errConf := k8s.Get(&r.client, types.NamespacedName{Namespace: config.SystemNamespace, Name: controllerConfName},
+	errConf := k8s.Get(&r.client, types.NamespacedName{Namespace: config.SystemNamespace, Name: common.ControllerConfName},
 		controlConf)
 
 	if errConf != nil {
@@ -163,29 +254,164 @@ func (r *ReconcileAPI) Reconcile(request reconcile.Request) (reconcile.Result, e
 			// Required configmap is not found. User should add the required config to proceed.
 			// Return and requeue
 			reqLogger.Error(errConf, "Required configmap is not found. Requeue request after 10 seconds")
+			instance.Status.LastError = errConf.Error()
 			return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, errConf
 	}
 
+	// Resolve the name, version, base path and the digest of the API
+	swaggerCM, paramsCM, certsCM, err := r.getAPIConfigMaps(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := resolveAPIInfo(instance, swaggerCM); err != nil {
+		reqLogger.Error(err, "Error resolving the name, version and base path of the API")
+		return reconcile.Result{}, err
+	}
+	// the API is not deployed to any target with invalid extensions, a change of the swagger configmap is watched
+	if err := validateSwaggerExtensions(swaggerCM); err != nil {
+		reqLogger.Error(err, "Invalid x-wso2 extensions in the swagger definition of the API")
+		r.recorder.Event(instance, eventTypeError, "InvalidSwagger", err.Error())
+		instance.Status.LastError = err.Error()
+		return reconcile.Result{}, nil
+	}
+	// target endpoints and endpoint groups referred by the API are watched to resolve the endpoints again when
+	// they are changed
+	backends, err := r.resolveTargetEndpoints(instance, swaggerCM)
+	if err != nil {
+		if !endpoints.IsUnresolved(err) {
+			return reconcile.Result{}, err
+		}
+		reason := "UnresolvedTargetEndpoint"
+		if endpoints.IsUnresolvedEndpointGroup(err) {
+			reason = "UnresolvedEndpointGroup"
+		}
+		reqLogger.Error(err, "Unable to resolve the target endpoints of the API")
+		r.recorder.Event(instance, eventTypeError, reason, err.Error())
+		instance.Status.LastError = err.Error()
+		return reconcile.Result{}, nil
+	}
+	// the API is not deployed to any target until the target endpoints have available replicas
+	if err := endpoints.CheckAvailable(backends); err != nil {
+		reqLogger.Info("Waiting for the target endpoints of the API", "reason", err.Error())
+		if cond := instance.Status.GetCondition(wso2v1alpha2.APIBackendAvailable); cond == nil ||
+			cond.Status != corev1.ConditionFalse {
+			r.recorder.Event(instance, corev1.EventTypeNormal, reasonWaitingForBackend, err.Error())
+		}
+		instance.Status.SetCondition(wso2v1alpha2.APIBackendAvailable, corev1.ConditionFalse,
+			reasonWaitingForBackend, err.Error())
+		return reconcile.Result{}, nil
+	}
+	if !backends.Empty() {
+		instance.Status.SetCondition(wso2v1alpha2.APIBackendAvailable, corev1.ConditionTrue, reasonBackendAvailable,
+			"Target endpoints of the API have available replicas")
+	} else {
+		instance.Status.RemoveCondition(wso2v1alpha2.APIBackendAvailable)
+	}
+	deploymentValues, err := params.GetDeploymentValues(&r.client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if deploymentValues != nil {
+		// the API is not deployed to any target with invalid params, a change of the params configmap is watched
+		if err := deploymentValues.Validate(); err != nil {
+			reqLogger.Error(err, "Invalid params of the API", "params_environment", deploymentValues.Environment)
+			r.recorder.Event(instance, eventTypeError, "InvalidParams", err.Error())
+			instance.Status.LastError = err.Error()
+			return reconcile.Result{}, nil
+		}
+	}
+	digestConfigMaps := []*corev1.ConfigMap{swaggerCM, paramsCM, certsCM}
+	if deploymentValues != nil {
+		// the params environment may be changed in the controller configs without changing the API generation
+		digestConfigMaps = append(digestConfigMaps, &corev1.ConfigMap{Data: map[string]string{
+			paramsEnvironmentKey: deploymentValues.Environment,
+		}})
+	}
+	if deploymentValues != nil && len(deploymentValues.SecretVersions()) != 0 {
+		// changes of the secret values referred by the API are detected with the versions of the secrets
+		digestConfigMaps = append(digestConfigMaps, &corev1.ConfigMap{Data: deploymentValues.SecretVersions()})
+	}
+	if deploymentValues != nil && deploymentValues.EndpointSecurity != nil &&
+		instance.Spec.EndpointSecurity == nil {
+		// changes of the endpoint security of the target endpoints are not changes of the API generation
+		digestConfigMaps = append(digestConfigMaps, &corev1.ConfigMap{Data: map[string]string{
+			endpointSecurityKey: deploymentValues.EndpointSecurity.String(),
+		}})
+	}
+	apiDigest := digest.ConfigMaps(digestConfigMaps...)
+	apiChanged := instance.Status.Digest != apiDigest || instance.Status.ObservedGeneration != instance.Generation
+	if apiChanged {
+		reqLogger.Info("API is changed", "old_digest", instance.Status.Digest, "new_digest", apiDigest)
+	}
+	instance.Status.Digest = apiDigest
+
 	controlConfigData := controlConf.Data
-	deployAPIMEnabled, err := strconv.ParseBool(controlConfigData[deployAPIMEnabledConst])
+	deployAPIMEnabled, err := strconv.ParseBool(controlConfigData[common.DeployAPIMEnabledConst])
 	if err != nil {
 		reqLogger.Error(err, "Invalid boolean value for deployAPIMEnabled",
-			"value", controlConfigData[deployAPIMEnabledConst])
+			"value", controlConfigData[common.DeployAPIMEnabledConst])
 		return reconcile.Result{RequeueAfter: common.RequeueDurationForConfigError}, err
 	}
-	if deployAPIMEnabled {
-		importErr := apim.ImportAPI(&r.client, instance)
+	if deployAPIMEnabled && !apiChanged && instance.Status.IsConditionTrue(wso2v1alpha2.APIImportedToAPIM) {
+		reqLogger.Info("API is not changed and already imported to APIM. Skip importing the API")
+	} else if msg := unsupportedSwaggerImport(swaggerCM, deploymentValues); deployAPIMEnabled && msg != "" {
+		// the API is not imported to APIM rather than importing it with the params and certs dropped
+		reqLogger.Info("Skip importing the API to APIM", "reason", msg)
+		r.recorder.Event(instance, eventTypeError, "UnsupportedParams", msg)
+		instance.Status.SetCondition(wso2v1alpha2.APIImportedToAPIM, corev1.ConditionFalse, reasonUnsupportedParams,
+			msg)
+		instance.Status.LastError = msg
+	} else if deployAPIMEnabled {
+		apiId, importErr := apim.ImportAPI(&r.client, instance)
 		if importErr != nil {
 			r.recorder.Event(instance, eventTypeError, "FailedAPIImport",
 				fmt.Sprintf("Error occured while importing the API to APIM"))
+			instance.Status.SetCondition(wso2v1alpha2.APIImportedToAPIM, corev1.ConditionFalse, reasonImportFailed,
+				importErr.Error())
 			return reconcile.Result{}, importErr
 		}
 		r.recorder.Event(instance, corev1.EventTypeNormal, "APIImport",
 			fmt.Sprintf("Successfully imported the API to APIM"))
 		reqLogger.Info("Successfully imported the API to APIM", "api_name", instance.Name)
+		instance.Status.APIMID = apiId
+		instance.Status.SetCondition(wso2v1alpha2.APIImportedToAPIM, corev1.ConditionTrue, reasonImported,
+			"Successfully imported the API to APIM")
+	} else {
+		instance.Status.APIMID = ""
+		instance.Status.RemoveCondition(wso2v1alpha2.APIImportedToAPIM)
+	}
+
+	// Change the lifecycle state of the API in APIM
+	lifecycleState := instance.Spec.LifecycleState
+	if !deployAPIMEnabled || lifecycleState == "" {
+		instance.Status.RemoveCondition(wso2v1alpha2.APILifecycleStateReached)
+		if !deployAPIMEnabled {
+			instance.Status.LifecycleState = ""
+		}
+	} else if !instance.Status.IsConditionTrue(wso2v1alpha2.APIImportedToAPIM) {
+		reqLogger.Info("API is not imported to APIM. Skip changing the lifecycle state")
+	} else if !apiChanged && instance.Status.LifecycleState == lifecycleState &&
+		instance.Status.IsConditionTrue(wso2v1alpha2.APILifecycleStateReached) {
+		reqLogger.Info("Lifecycle state of the API is not changed. Skip changing the lifecycle state")
+	} else {
+		currentState, lcErr := apim.ChangeLifecycleState(&r.client, instance.Status.APIMID, lifecycleState)
+		if currentState != "" {
+			instance.Status.LifecycleState = currentState
+		}
+		if lcErr != nil {
+			r.recorder.Event(instance, eventTypeError, "FailedAPILifecycleChange",
+				fmt.Sprintf("Error occured while changing the lifecycle state of the API to %s", lifecycleState))
+			instance.Status.SetCondition(wso2v1alpha2.APILifecycleStateReached, corev1.ConditionFalse,
+				reasonLifecycleChangeFailed, lcErr.Error())
+			return reconcile.Result{}, lcErr
+		}
+		r.recorder.Event(instance, corev1.EventTypeNormal, "APILifecycleChange",
+			fmt.Sprintf("Successfully changed the lifecycle state of the API to %s", lifecycleState))
+		instance.Status.SetCondition(wso2v1alpha2.APILifecycleStateReached, corev1.ConditionTrue,
+			reasonLifecycleChanged, fmt.Sprintf("Lifecycle state of the API is %s", lifecycleState))
 	}
 
 	// Deploy the API to MGW Adapter
@@ -193,19 +419,89 @@ func (r *ReconcileAPI) Reconcile(request reconcile.Request) (reconcile.Result, e
 	if err != nil {
 		reqLogger.Error(err, "Invalid boolean value for deployAPIToMGWEnabled",
 			"value", controlConfigData[deployAPIToMGWEnabledConst])
+		instance.Status.LastError = err.Error()
 		return reconcile.Result{RequeueAfter: common.RequeueDurationForConfigError}, nil
 	}
 
-	if deployMgwEnabled {
-		deployErr := envoy.DeployAPItoMgw(&r.client, instance)
+	if deployMgwEnabled && !apiChanged && instance.Status.IsConditionTrue(wso2v1alpha2.APIDeployedToMicrogateway) {
+		reqLogger.Info("API is not changed and already deployed to Envoy MGW Adapter. Skip deploying the API")
+	} else if deployMgwEnabled {
+		// override the API in MGW Adapter if it was deployed before
+		override := instance.Status.GetCondition(wso2v1alpha2.APIDeployedToMicrogateway) != nil
+		deployErr := envoy.DeployAPItoMgw(&r.client, instance, override)
 		if deployErr != nil {
 			r.recorder.Event(instance, eventTypeError, "FailedAPIDeployToMGW",
 				fmt.Sprintf("Error occured while deploying API to Envoy MGW Adapter"))
+			instance.Status.SetCondition(wso2v1alpha2.APIDeployedToMicrogateway, corev1.ConditionFalse,
+				reasonDeployFailed, deployErr.Error())
 			return reconcile.Result{}, deployErr
 		}
 		r.recorder.Event(instance, corev1.EventTypeNormal, "APIDeploy",
 			fmt.Sprintf("Successfully deployed API to Envoy MGW Adapter"))
 		reqLogger.Info("Successfully deployed API to Envoy MGW Adapter", "api_name", instance.Name)
+		instance.Status.SetCondition(wso2v1alpha2.APIDeployedToMicrogateway, corev1.ConditionTrue, reasonDeployed,
+			"Successfully deployed API to Envoy MGW Adapter")
+	} else {
+		instance.Status.RemoveCondition(wso2v1alpha2.APIDeployedToMicrogateway)
 	}
 	return reconcile.Result{}, nil
 }
+
+// resolveTargetEndpoints sets the target endpoints and the endpoint groups referred by the API in the status,
+// replaces the endpoints referring them in the given swagger configmap with the URLs of their services and members
+// and returns them
+func (r *ReconcileAPI) resolveTargetEndpoints(api *wso2v1alpha2.API, swaggerCM *corev1.ConfigMap) (
+	*endpoints.Backends, error) {
+	names, groupNames, err := endpoints.References(api, swaggerCM)
+	if err != nil {
+		return nil, err
+	}
+	api.Status.TargetEndpoints = nil
+	for _, name := range names {
+		api.Status.TargetEndpoints = append(api.Status.TargetEndpoints, name.String())
+	}
+	api.Status.EndpointGroups = nil
+	for _, name := range groupNames {
+		api.Status.EndpointGroups = append(api.Status.EndpointGroups, name.String())
+	}
+
+	resolvedCM, backends, err := endpoints.ResolveSwagger(&r.client, api, swaggerCM)
+	if err != nil {
+		return nil, err
+	}
+	*swaggerCM = *resolvedCM
+	return backends, nil
+}
+
+// getAPIConfigMaps returns the swagger, params and certs configmaps of the API. Params and certs configmaps are nil
+// if they are not specified or not found
+func (r *ReconcileAPI) getAPIConfigMaps(api *wso2v1alpha2.API) (swaggerCM, paramsCM, certsCM *corev1.ConfigMap,
+	err error) {
+	swaggerCM = k8s.NewConfMap()
+	err = k8s.Get(&r.client, types.NamespacedName{Namespace: api.Namespace, Name: api.Spec.SwaggerConfigMapName},
+		swaggerCM)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	getOptional := func(name string) (*corev1.ConfigMap, error) {
+		if name == "" {
+			return nil, nil
+		}
+		cm := k8s.NewConfMap()
+		if err := k8s.Get(&r.client, types.NamespacedName{Namespace: api.Namespace, Name: name}, cm); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return cm, nil
+	}
+	if paramsCM, err = getOptional(api.Spec.ParamsValues); err != nil {
+		return nil, nil, nil, err
+	}
+	if certsCM, err = getOptional(api.Spec.CertsValues); err != nil {
+		return nil, nil, nil, err
+	}
+	return swaggerCM, paramsCM, certsCM, nil
+}
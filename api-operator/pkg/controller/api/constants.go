@@ -16,10 +16,25 @@
 package api
 
 const (
-	controllerConfName         = "api-controller-config"
 	eventTypeError             = "Error"
-	deployAPIMEnabledConst     = "deployAPIToAPIManager"
 	deployAPIToMGWEnabledConst = "deployAPIToMicrogateway"
+	endpointSecurityKey        = "endpointSecurity"
+	paramsEnvironmentKey       = "paramsEnvironment"
 
 	finalizerName = "wso2.microgateway/api.finalizer"
 )
+
+// reasons of the API status conditions
+const (
+	reasonImported              = "Imported"
+	reasonImportFailed          = "ImportFailed"
+	reasonUnsupportedParams     = "UnsupportedParams"
+	reasonDeployed              = "Deployed"
+	reasonDeployFailed          = "DeployFailed"
+	reasonLifecycleChanged      = "LifecycleChanged"
+	reasonLifecycleChangeFailed = "LifecycleChangeFailed"
+	reasonReady                 = "Ready"
+	reasonNotReady              = "NotReady"
+	reasonBackendAvailable      = "BackendAvailable"
+	reasonWaitingForBackend     = "WaitingForBackend"
+)
@@ -20,6 +20,7 @@ import (
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
 	"k8s.io/apimachinery/pkg/types"
@@ -31,7 +32,7 @@ var logFinalize = log2.Log.WithName("Finalizer")
 func (r *ReconcileAPI) finalizeDeletion(api *wso2v1alpha2.API) error {
 
 	controlConf := k8s.NewConfMap()
-	errConf := k8s.Get(&r.client, types.NamespacedName{Namespace: config.SystemNamespace, Name: controllerConfName},
+	errConf := k8s.Get(&r.client, types.NamespacedName{Namespace: config.SystemNamespace, Name: common.ControllerConfName},
 		controlConf)
 	if errConf != nil {
 		return errConf
@@ -39,10 +40,10 @@ func (r *ReconcileAPI) finalizeDeletion(api *wso2v1alpha2.API) error {
 
 	controlConfigData := controlConf.Data
 	// Delete the API from API Manager
-	deployAPIMEnabled, err := strconv.ParseBool(controlConfigData[deployAPIMEnabledConst])
+	deployAPIMEnabled, err := strconv.ParseBool(controlConfigData[common.DeployAPIMEnabledConst])
 	if err != nil {
 		logFinalize.Error(err, "Invalid boolean value for deployAPIMEnabled",
-			"value", controlConfigData[deployAPIMEnabledConst])
+			"value", controlConfigData[common.DeployAPIMEnabledConst])
 		return  err
 	}
 	if deployAPIMEnabled {
@@ -0,0 +1,72 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"context"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"reflect"
+)
+
+// resolveAPIInfo sets the name, version and base path of the API in the status from the swagger definition or
+// the project zip
+func resolveAPIInfo(api *wso2v1alpha2.API, swaggerCM *corev1.ConfigMap) error {
+	apiInfo, err := apim.GetAPIInfo(swaggerCM)
+	if err != nil {
+		return err
+	}
+	api.Status.Name = apiInfo.Name
+	api.Status.Version = apiInfo.Version
+	api.Status.BasePath = apiInfo.Context
+	return nil
+}
+
+// updateStatus sets the Ready condition and the observed generation of the API and updates the status
+// sub-resource if it is changed
+func (r *ReconcileAPI) updateStatus(ctx context.Context, api *wso2v1alpha2.API,
+	oldStatus *wso2v1alpha2.APIStatus) error {
+	ready := api.Status.LastError == ""
+	for _, condType := range []wso2v1alpha2.APIConditionType{
+		wso2v1alpha2.APIImportedToAPIM,
+		wso2v1alpha2.APIDeployedToMicrogateway,
+		wso2v1alpha2.APILifecycleStateReached,
+	} {
+		if cond := api.Status.GetCondition(condType); cond != nil && cond.Status != corev1.ConditionTrue {
+			ready = false
+		}
+	}
+
+	backendCond := api.Status.GetCondition(wso2v1alpha2.APIBackendAvailable)
+	if ready && backendCond != nil && backendCond.Status != corev1.ConditionTrue {
+		// the API is not deployed until the backend is available, which is not an error
+		api.Status.SetCondition(wso2v1alpha2.APIReady, corev1.ConditionFalse, reasonWaitingForBackend,
+			backendCond.Message)
+	} else if ready {
+		api.Status.SetCondition(wso2v1alpha2.APIReady, corev1.ConditionTrue, reasonReady,
+			"API is deployed to all the enabled targets")
+	} else {
+		api.Status.SetCondition(wso2v1alpha2.APIReady, corev1.ConditionFalse, reasonNotReady, api.Status.LastError)
+	}
+	api.Status.ObservedGeneration = api.Generation
+
+	if reflect.DeepEqual(oldStatus, &api.Status) {
+		return nil
+	}
+	return r.client.Status().Update(ctx, api)
+}
@@ -0,0 +1,59 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/swagger"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// validateSwaggerExtensions returns the swagger.ValidationErrors of the x-wso2 extensions in the swagger definition
+// of the API. Extensions of a project zip are validated by the deployment targets.
+func validateSwaggerExtensions(swaggerCM *corev1.ConfigMap) error {
+	if swaggerCM.BinaryData != nil {
+		return nil
+	}
+	swaggerFileName, err := maps.OneKey(swaggerCM.Data)
+	if err != nil {
+		return err
+	}
+	swaggerData := swaggerCM.Data[swaggerFileName]
+	swaggerDoc, err := swagger.GetSwaggerV3(&swaggerData)
+	if err != nil {
+		return err
+	}
+	_, err = swagger.GetExtensions(swaggerDoc)
+	return err
+}
+
+// unsupportedSwaggerImport returns the reason the API can not be imported to APIM with its swagger definition, or an
+// empty string if it can be. The params and certs configmaps are applied to a project zip and a swagger definition
+// has no place for them. The endpoint security is not a reason, as it is not defined in a params configmap.
+func unsupportedSwaggerImport(swaggerCM *corev1.ConfigMap, deploymentValues *params.DeploymentValues) string {
+	if swaggerCM.BinaryData != nil || deploymentValues == nil || deploymentValues.ParamsCM == nil {
+		return ""
+	}
+	dropped := fmt.Sprintf("params configmap %q", deploymentValues.ParamsCM.Name)
+	if deploymentValues.CertsCM != nil {
+		dropped += fmt.Sprintf(" and certs configmap %q", deploymentValues.CertsCM.Name)
+	}
+	return dropped + " of the API can not be applied when importing a swagger definition to APIM, use an API " +
+		"project zip to import the API with them"
+}
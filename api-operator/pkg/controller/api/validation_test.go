@@ -0,0 +1,51 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/endpoints"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
+	"strings"
+	"testing"
+)
+
+func TestUnsupportedSwaggerImport(t *testing.T) {
+	swaggerCM := k8s.NewConfMap()
+	swaggerCM.Data = map[string]string{"swagger.yaml": "openapi: 3.0.0"}
+	security := &wso2v1alpha2.EndpointSecurity{Type: "basic", SecretName: "backend"}
+	values := &params.DeploymentValues{EndpointSecurity: &endpoints.EndpointSecurity{Production: security}}
+	if msg := unsupportedSwaggerImport(swaggerCM, values); msg != "" {
+		t.Errorf("expected the API with endpoint security but without params to be imported but was %q", msg)
+	}
+	if msg := unsupportedSwaggerImport(swaggerCM, nil); msg != "" {
+		t.Errorf("expected the API without deployment values to be imported but was %q", msg)
+	}
+
+	values.ParamsCM = k8s.NewConfMap()
+	values.ParamsCM.Name = "params-cm"
+	if msg := unsupportedSwaggerImport(swaggerCM, values); !strings.Contains(msg, `params configmap "params-cm"`) {
+		t.Errorf("expected the dropped params configmap named in the reason but was %q", msg)
+	}
+
+	zipCM := k8s.NewConfMap()
+	zipCM.BinaryData = map[string][]byte{"api.zip": {}}
+	if msg := unsupportedSwaggerImport(zipCM, values); msg != "" {
+		t.Errorf("expected the project zip with params to be imported but was %q", msg)
+	}
+}
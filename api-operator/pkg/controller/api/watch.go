@@ -0,0 +1,247 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"context"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// configMapIndexKey is the field index of APIs by the names of the configmaps referred by them
+const configMapIndexKey = "spec.configMapRefs"
+
+// apiConfigMapIndexer returns the names of the swagger, params and certs configmaps referred by the API
+func apiConfigMapIndexer(obj runtime.Object) []string {
+	api, ok := obj.(*wso2v1alpha2.API)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, name := range []string{api.Spec.SwaggerConfigMapName, api.Spec.ParamsValues, api.Spec.CertsValues} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// configMapToAPIsMapper maps a configmap to the reconcile requests of the APIs referring it
+type configMapToAPIsMapper struct {
+	client client.Client
+}
+
+// Map implements handler.Mapper
+func (m *configMapToAPIsMapper) Map(obj handler.MapObject) []reconcile.Request {
+	apiList := &wso2v1alpha2.APIList{}
+	err := m.client.List(context.TODO(), apiList, client.InNamespace(obj.Meta.GetNamespace()),
+		client.MatchingFields{configMapIndexKey: obj.Meta.GetName()})
+	if err != nil {
+		log.Error(err, "Error listing APIs referring the configmap", "namespace", obj.Meta.GetNamespace(),
+			"configmap", obj.Meta.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(apiList.Items))
+	for _, api := range apiList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: api.Namespace, Name: api.Name},
+		})
+	}
+	if len(requests) > 0 {
+		log.Info("Configmap referred by APIs is changed", "namespace", obj.Meta.GetNamespace(),
+			"configmap", obj.Meta.GetName(), "api_count", len(requests))
+	}
+	return requests
+}
+
+// targetEndpointIndexKey is the field index of APIs by the target endpoints referred by them
+const targetEndpointIndexKey = "status.targetEndpoints"
+
+// apiTargetEndpointIndexer returns the target endpoints referred by the API as "<namespace>/<name>"
+func apiTargetEndpointIndexer(obj runtime.Object) []string {
+	api, ok := obj.(*wso2v1alpha2.API)
+	if !ok {
+		return nil
+	}
+	return api.Status.TargetEndpoints
+}
+
+// endpointGroupIndexKey is the field index of APIs by the endpoint groups referred by them
+const endpointGroupIndexKey = "status.endpointGroups"
+
+// apiEndpointGroupIndexer returns the endpoint groups referred by the API as "<namespace>/<name>"
+func apiEndpointGroupIndexer(obj runtime.Object) []string {
+	api, ok := obj.(*wso2v1alpha2.API)
+	if !ok {
+		return nil
+	}
+	return api.Status.EndpointGroups
+}
+
+// memberIndexKey is the field index of endpoint groups by the names of the target endpoints in their members
+const memberIndexKey = "spec.members.targetEndpoint"
+
+// endpointGroupMemberIndexer returns the names of the target endpoints in the members of the endpoint group
+func endpointGroupMemberIndexer(obj runtime.Object) []string {
+	group, ok := obj.(*wso2v1alpha2.EndpointGroup)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, member := range group.Spec.Members {
+		if member.TargetEndpoint != "" {
+			names = append(names, member.TargetEndpoint)
+		}
+	}
+	return names
+}
+
+// targetEndpointToAPIsMapper maps a target endpoint to the reconcile requests of the APIs referring it directly or
+// through endpoint groups
+type targetEndpointToAPIsMapper struct {
+	client client.Client
+}
+
+// Map implements handler.Mapper
+func (m *targetEndpointToAPIsMapper) Map(obj handler.MapObject) []reconcile.Request {
+	name := types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()}
+	// APIs in any namespace may refer the target endpoint
+	requests, err := listAPIRequests(m.client, targetEndpointIndexKey, name.String())
+	if err != nil {
+		log.Error(err, "Error listing APIs referring the target endpoint", "namespace", name.Namespace,
+			"target_endpoint", name.Name)
+		return nil
+	}
+
+	// endpoint groups refer the target endpoints in their namespace
+	groupList := &wso2v1alpha2.EndpointGroupList{}
+	err = m.client.List(context.TODO(), groupList, client.InNamespace(name.Namespace),
+		client.MatchingFields{memberIndexKey: name.Name})
+	if err != nil {
+		log.Error(err, "Error listing endpoint groups with the target endpoint", "namespace", name.Namespace,
+			"target_endpoint", name.Name)
+		return nil
+	}
+	for _, group := range groupList.Items {
+		groupRequests, err := listAPIRequests(m.client, endpointGroupIndexKey, group.Namespace+"/"+group.Name)
+		if err != nil {
+			log.Error(err, "Error listing APIs referring the endpoint group", "namespace", group.Namespace,
+				"endpoint_group", group.Name)
+			return nil
+		}
+		requests = appendRequests(requests, groupRequests...)
+	}
+
+	if len(requests) > 0 {
+		log.Info("Target endpoint referred by APIs is changed", "namespace", name.Namespace,
+			"target_endpoint", name.Name, "api_count", len(requests))
+	}
+	return requests
+}
+
+// endpointGroupToAPIsMapper maps an endpoint group to the reconcile requests of the APIs referring it
+type endpointGroupToAPIsMapper struct {
+	client client.Client
+}
+
+// Map implements handler.Mapper
+func (m *endpointGroupToAPIsMapper) Map(obj handler.MapObject) []reconcile.Request {
+	name := types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()}
+	requests, err := listAPIRequests(m.client, endpointGroupIndexKey, name.String())
+	if err != nil {
+		log.Error(err, "Error listing APIs referring the endpoint group", "namespace", name.Namespace,
+			"endpoint_group", name.Name)
+		return nil
+	}
+	if len(requests) > 0 {
+		log.Info("Endpoint group referred by APIs is changed", "namespace", name.Namespace,
+			"endpoint_group", name.Name, "api_count", len(requests))
+	}
+	return requests
+}
+
+// listAPIRequests returns the reconcile requests of the APIs in any namespace with the given value of the given
+// field index
+func listAPIRequests(c client.Client, indexKey, value string) ([]reconcile.Request, error) {
+	apiList := &wso2v1alpha2.APIList{}
+	if err := c.List(context.TODO(), apiList, client.MatchingFields{indexKey: value}); err != nil {
+		return nil, err
+	}
+
+	requests := make([]reconcile.Request, 0, len(apiList.Items))
+	for _, api := range apiList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: api.Namespace, Name: api.Name},
+		})
+	}
+	return requests, nil
+}
+
+// appendRequests appends the given requests which are not already in the requests
+func appendRequests(requests []reconcile.Request, newRequests ...reconcile.Request) []reconcile.Request {
+	for _, newRequest := range newRequests {
+		found := false
+		for _, request := range requests {
+			if request == newRequest {
+				found = true
+				break
+			}
+		}
+		if !found {
+			requests = append(requests, newRequest)
+		}
+	}
+	return requests
+}
+
+// configMapDataChangedPredicate filters out configmap update events that do not change the configmap data
+var configMapDataChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldConf, okOld := e.ObjectOld.(*corev1.ConfigMap)
+		newConf, okNew := e.ObjectNew.(*corev1.ConfigMap)
+		if !okOld || !okNew {
+			return true
+		}
+		return !reflect.DeepEqual(oldConf.Data, newConf.Data) ||
+			!reflect.DeepEqual(oldConf.BinaryData, newConf.BinaryData)
+	},
+}
+
+// targetEndpointChangedPredicate filters out target endpoint update events that change neither the spec nor the
+// available replicas of the target endpoint
+var targetEndpointChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldTargetEndpoint, okOld := e.ObjectOld.(*wso2v1alpha2.TargetEndpoint)
+		newTargetEndpoint, okNew := e.ObjectNew.(*wso2v1alpha2.TargetEndpoint)
+		if !okOld || !okNew {
+			return true
+		}
+		return oldTargetEndpoint.Generation != newTargetEndpoint.Generation ||
+			oldTargetEndpoint.Status.AvailableReplicas != newTargetEndpoint.Status.AvailableReplicas
+	},
+}
@@ -0,0 +1,268 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apiproduct
+
+import (
+	"context"
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+)
+
+var log = logf.Log.WithName("apiproduct.controller")
+
+// Add creates a new APIProduct Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileAPIProduct{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("apiproduct-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("apiproduct-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource APIProduct
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.APIProduct{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Index APIProducts by the APIs referred by them
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.APIProduct{}, apiNameIndexKey,
+		func(obj runtime.Object) []string {
+			apis := obj.(*wso2v1alpha2.APIProduct).Spec.APIs
+			names := make([]string, 0, len(apis))
+			for _, api := range apis {
+				names = append(names, api.Name)
+			}
+			return names
+		})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to APIs and requeue the APIProducts referring them, as the IDs of the APIs in APIM are
+	// resolved after they are imported
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.API{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &apiToProductsMapper{client: mgr.GetClient()},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the controller configs and requeue all the APIProducts, as they are synced to APIM only if
+	// deploying to APIM is enabled
+	err = common.WatchAPIMEnabled(c, mgr.GetClient(), &wso2v1alpha2.APIProductList{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAPIProduct{}
+
+// ReconcileAPIProduct reconciles a APIProduct object
+type ReconcileAPIProduct struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile creates or updates the API product in APIM with the resources of the referred APIs
+func (r *ReconcileAPIProduct) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("request_namespace", request.Namespace, "request_name", request.Name)
+	reqLogger.Info("Reconciling APIProduct")
+
+	instance := &wso2v1alpha2.APIProduct{}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	requestInfo := &common.RequestInfo{Request: request, Client: r.client, Object: instance, Log: log,
+		EvnRecorder: r.recorder}
+
+	err := k8s.Get(&r.client, request.NamespacedName, instance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Handle deletion with finalizers
+	if _, finUpdated, err := k8s.HandleObjectDeletion(ctx, requestInfo, finalizerName, func() error {
+		return r.finalizeDeletion(instance)
+	}); finUpdated || err != nil {
+		return reconcile.Result{}, err
+	}
+
+	oldStatus := instance.Status.DeepCopy()
+	instance.Status.LastError = ""
+	result, err := r.syncAPIProduct(instance, oldStatus)
+	if err != nil {
+		instance.Status.LastError = err.Error()
+		r.recorder.Event(instance, corev1.EventTypeWarning, "FailedAPIProductSync",
+			"Error occurred while syncing the API product to APIM")
+	}
+	instance.Status.ObservedGeneration = instance.Generation
+	return common.CompleteSync(ctx, requestInfo, !reflect.DeepEqual(oldStatus, &instance.Status), result, err)
+}
+
+// syncAPIProduct creates or updates the API product in APIM once all the referred APIs are imported to APIM. Syncing
+// is skipped if neither the APIProduct nor the IDs of the referred APIs are changed since the last successful sync
+// recorded in the old status.
+func (r *ReconcileAPIProduct) syncAPIProduct(instance *wso2v1alpha2.APIProduct,
+	oldStatus *wso2v1alpha2.APIProductStatus) (reconcile.Result, error) {
+	if enabled, result, err := common.CheckAPIMEnabled(&r.client, r.recorder, instance); !enabled {
+		return result, err
+	}
+
+	// the product is synced when a referred API is imported, as APIs are watched
+	instance.Status.MissingAPIs = nil
+	instance.Status.UnimportedAPIs = nil
+	apiIds := make(map[string]string, len(instance.Spec.APIs))
+	for _, productAPI := range instance.Spec.APIs {
+		api := &wso2v1alpha2.API{}
+		if err := k8s.Get(&r.client, types.NamespacedName{Namespace: instance.Namespace, Name: productAPI.Name},
+			api); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return reconcile.Result{}, err
+			}
+			instance.Status.MissingAPIs = append(instance.Status.MissingAPIs, productAPI.Name)
+			continue
+		}
+		if api.Status.APIMID == "" {
+			instance.Status.UnimportedAPIs = append(instance.Status.UnimportedAPIs, productAPI.Name)
+			continue
+		}
+		apiIds[productAPI.Name] = api.Status.APIMID
+	}
+	if len(instance.Status.MissingAPIs) != 0 {
+		instance.Status.LastError = fmt.Sprintf("waiting for the APIs %s to be created",
+			strings.Join(instance.Status.MissingAPIs, ", "))
+		return reconcile.Result{}, nil
+	}
+	if len(instance.Status.UnimportedAPIs) != 0 {
+		instance.Status.LastError = fmt.Sprintf("waiting for the APIs %s to be imported to APIM",
+			strings.Join(instance.Status.UnimportedAPIs, ", "))
+		return reconcile.Result{}, nil
+	}
+
+	if oldStatus.ObservedGeneration == instance.Generation && oldStatus.LastError == "" &&
+		oldStatus.APIMID != "" && equalAPIIds(oldStatus.APIIDs, apiIds) {
+		return reconcile.Result{}, nil
+	}
+
+	productId, err := apim.SyncAPIProduct(&r.client, instance, apiIds)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instance.Status.APIMID = productId
+	instance.Status.APIIDs = apiIds
+
+	log.Info("Successfully synced the API product to APIM", "namespace", instance.Namespace,
+		"name", instance.Name, "apim_id", productId)
+	r.recorder.Event(instance, corev1.EventTypeNormal, "APIProductSync",
+		fmt.Sprintf("Successfully synced the API product %s to APIM", apim.APIProductName(instance)))
+	return reconcile.Result{}, nil
+}
+
+// finalizeDeletion deletes the API product from APIM
+func (r *ReconcileAPIProduct) finalizeDeletion(instance *wso2v1alpha2.APIProduct) error {
+	if instance.Status.APIMID == "" {
+		return nil
+	}
+	enabled, err := common.IsAPIMEnabled(&r.client)
+	if err != nil || !enabled {
+		return err
+	}
+	if err := apim.DeleteAPIProduct(&r.client, instance.Status.APIMID); err != nil {
+		return err
+	}
+	log.Info("Successfully deleted the API product from APIM", "namespace", instance.Namespace,
+		"name", instance.Name)
+	return nil
+}
+
+// apiToProductsMapper maps an API to the reconcile requests of the APIProducts referring it
+type apiToProductsMapper struct {
+	client client.Client
+}
+
+// Map implements handler.Mapper
+func (m *apiToProductsMapper) Map(obj handler.MapObject) []reconcile.Request {
+	productList := &wso2v1alpha2.APIProductList{}
+	err := m.client.List(context.TODO(), productList, client.InNamespace(obj.Meta.GetNamespace()),
+		client.MatchingFields{apiNameIndexKey: obj.Meta.GetName()})
+	if err != nil {
+		log.Error(err, "Error listing APIProducts referring the API", "namespace", obj.Meta.GetNamespace(),
+			"name", obj.Meta.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(productList.Items))
+	for _, product := range productList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: product.Namespace, Name: product.Name},
+		})
+	}
+	return requests
+}
+
+// equalAPIIds returns whether the given IDs of APIs by their names are equal
+func equalAPIIds(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, id := range a {
+		if bId, ok := b[name]; !ok || bId != id {
+			return false
+		}
+	}
+	return true
+}
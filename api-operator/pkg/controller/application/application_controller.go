@@ -0,0 +1,225 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("application.controller")
+
+// Add creates a new Application Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileApplication{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("application-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("application-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource Application
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.Application{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the secrets with the keys of Applications, to restore them if they are changed
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &wso2v1alpha2.Application{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the controller configs and requeue all the Applications, as they are synced to APIM only if
+	// deploying to APIM is enabled
+	err = common.WatchAPIMEnabled(c, mgr.GetClient(), &wso2v1alpha2.ApplicationList{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileApplication{}
+
+// ReconcileApplication reconciles a Application object
+type ReconcileApplication struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile creates or updates the application in APIM, generates its keys and writes them to a secret owned by the
+// Application
+func (r *ReconcileApplication) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("request_namespace", request.Namespace, "request_name", request.Name)
+	reqLogger.Info("Reconciling Application")
+
+	instance := &wso2v1alpha2.Application{}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	requestInfo := &common.RequestInfo{Request: request, Client: r.client, Object: instance, Log: log,
+		EvnRecorder: r.recorder}
+
+	err := k8s.Get(&r.client, request.NamespacedName, instance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Handle deletion with finalizers
+	if _, finUpdated, err := k8s.HandleObjectDeletion(ctx, requestInfo, finalizerName, func() error {
+		return r.finalizeDeletion(instance)
+	}); finUpdated || err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// the application is synced to APIM only if it is changed since the last successful sync
+	synced := instance.Status.ObservedGeneration == instance.Generation && instance.Status.LastError == "" &&
+		instance.Status.ApplicationID != ""
+	oldStatus := instance.Status.DeepCopy()
+	instance.Status.LastError = ""
+	result, err := r.syncApplication(ctx, instance, synced)
+	if err != nil {
+		instance.Status.LastError = err.Error()
+		r.recorder.Event(instance, corev1.EventTypeWarning, "FailedApplicationSync",
+			"Error occurred while syncing the application to APIM")
+	}
+	instance.Status.ObservedGeneration = instance.Generation
+	return common.CompleteSync(ctx, requestInfo, !reflect.DeepEqual(oldStatus, &instance.Status), result, err)
+}
+
+// syncApplication syncs the application to APIM and writes the keys of the application to the key secret. Syncing
+// is skipped if the application is already synced and the keys in the key secret are intact.
+func (r *ReconcileApplication) syncApplication(ctx context.Context, instance *wso2v1alpha2.Application,
+	synced bool) (reconcile.Result, error) {
+	if enabled, result, err := common.CheckAPIMEnabled(&r.client, r.recorder, instance); !enabled {
+		return result, err
+	}
+
+	if synced {
+		intact, err := r.isKeySecretIntact(instance)
+		if err != nil || intact {
+			return reconcile.Result{}, err
+		}
+	}
+
+	appId, key, err := apim.SyncApplication(&r.client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instance.Status.ApplicationID = appId
+
+	secretName := instance.Spec.Keys.SecretName
+	if secretName == "" {
+		secretName = instance.Name + keySecretNameSuffix
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: instance.Namespace, Name: secretName}}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, secret, func() error {
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[consumerKeyConst] = []byte(key.ConsumerKey)
+		// the consumer secret may not be returned for existing keys, keep the value in the secret in that case
+		if key.ConsumerSecret != "" {
+			secret.Data[consumerSecretConst] = []byte(key.ConsumerSecret)
+		}
+		return controllerutil.SetControllerReference(instance, secret, r.scheme)
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instance.Status.KeySecretName = secretName
+
+	log.Info("Successfully synced the application to APIM", "namespace", instance.Namespace, "name", instance.Name,
+		"application_id", appId)
+	r.recorder.Event(instance, corev1.EventTypeNormal, "ApplicationSync",
+		fmt.Sprintf("Successfully synced the application to APIM and wrote the keys to the secret %s", secretName))
+	return reconcile.Result{}, nil
+}
+
+// finalizeDeletion deletes the application from APIM
+func (r *ReconcileApplication) finalizeDeletion(instance *wso2v1alpha2.Application) error {
+	if instance.Status.ApplicationID == "" {
+		return nil
+	}
+	enabled, err := common.IsAPIMEnabled(&r.client)
+	if err != nil || !enabled {
+		return err
+	}
+	if err := apim.DeleteApplication(&r.client, instance.Status.ApplicationID); err != nil {
+		return err
+	}
+	log.Info("Successfully deleted the application from APIM", "namespace", instance.Namespace,
+		"name", instance.Name)
+	return nil
+}
+
+// isKeySecretIntact returns whether the key secret of the synced application has the keys of the application
+func (r *ReconcileApplication) isKeySecretIntact(instance *wso2v1alpha2.Application) (bool, error) {
+	secret := &corev1.Secret{}
+	err := k8s.Get(&r.client, types.NamespacedName{Namespace: instance.Namespace,
+		Name: instance.Status.KeySecretName}, secret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(secret.Data[consumerKeyConst]) != 0 && len(secret.Data[consumerSecretConst]) != 0, nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package application
+
+const (
+	finalizerName = "wso2.com/application.finalizer"
+
+	// keys of the secret with the consumer key and secret of the application
+	consumerKeyConst    = "consumerKey"
+	consumerSecretConst = "consumerSecret"
+	// keySecretNameSuffix is the suffix of the default name of the secret with the keys of the application
+	keySecretNameSuffix = "-keys"
+)
@@ -0,0 +1,153 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strconv"
+)
+
+var log = logf.Log.WithName("controller.common")
+
+const (
+	// ControllerConfName is the name of the configmap with the controller configs in the system namespace
+	ControllerConfName = "api-controller-config"
+	// DeployAPIMEnabledConst is the key of the controller config enabling deploying to API Manager
+	DeployAPIMEnabledConst = "deployAPIToAPIManager"
+
+	reasonAPIMDisabled = "APIMDisabled"
+)
+
+// IsAPIMEnabled returns whether deploying to APIM is enabled in the controller configs
+func IsAPIMEnabled(client *client.Client) (bool, error) {
+	controlConf := &corev1.ConfigMap{}
+	err := (*client).Get(context.TODO(), types.NamespacedName{Namespace: config.SystemNamespace,
+		Name: ControllerConfName}, controlConf)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(controlConf.Data[DeployAPIMEnabledConst])
+}
+
+// CheckAPIMEnabled returns whether deploying to APIM is enabled in the controller configs, with the result to
+// requeue the request if the controller configs are not found. An event is recorded for the given object if
+// deploying to APIM is disabled, it is reconciled again once enabled as the controller configs are watched with
+// WatchAPIMEnabled.
+func CheckAPIMEnabled(client *client.Client, recorder record.EventRecorder, obj runtime.Object) (bool,
+	reconcile.Result, error) {
+	enabled, err := IsAPIMEnabled(client)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, reconcile.Result{RequeueAfter: RequeueDurationForConfigError}, err
+		}
+		return false, reconcile.Result{}, err
+	}
+	if !enabled {
+		recorder.Event(obj, corev1.EventTypeNormal, reasonAPIMDisabled,
+			"Deploying to API Manager is disabled in the controller configs, skip syncing to API Manager")
+	}
+	return enabled, reconcile.Result{}, nil
+}
+
+// WatchAPIMEnabled watches the controller configs with the given controller and requeues all the objects of the
+// given list type when deploying to APIM is enabled or disabled
+func WatchAPIMEnabled(c controller.Controller, client client.Client, list runtime.Object) error {
+	return c.Watch(&source.Kind{Type: &corev1.ConfigMap{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &controllerConfigMapper{client: client, list: list}},
+		apimEnabledChangedPredicate)
+}
+
+// controllerConfigMapper maps the controller configmap to the reconcile requests of all the objects of the list type
+type controllerConfigMapper struct {
+	client client.Client
+	list   runtime.Object
+}
+
+// Map implements handler.Mapper
+func (m *controllerConfigMapper) Map(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta.GetNamespace() != config.SystemNamespace || obj.Meta.GetName() != ControllerConfName {
+		return nil
+	}
+	list := m.list.DeepCopyObject()
+	if err := m.client.List(context.TODO(), list); err != nil {
+		log.Error(err, "Error listing the objects to requeue for the changed controller configs")
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "Error extracting the objects to requeue for the changed controller configs")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if object, err := meta.Accessor(item); err == nil {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()},
+			})
+		}
+	}
+	return requests
+}
+
+// apimEnabledChangedPredicate filters out update events of configmaps that do not change enabling deploying to APIM
+var apimEnabledChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldObj, okOld := e.ObjectOld.(*corev1.ConfigMap)
+		newObj, okNew := e.ObjectNew.(*corev1.ConfigMap)
+		return !okOld || !okNew || oldObj.Data[DeployAPIMEnabledConst] != newObj.Data[DeployAPIMEnabledConst]
+	},
+}
+
+// CompleteSync updates the status of the object in the request if it is changed by syncing the object to APIM and
+// returns the result of the reconciliation. The request failed with a permanent error is requeued after a delay
+// instead of retrying immediately.
+func CompleteSync(ctx context.Context, requestInfo *RequestInfo, statusChanged bool, result reconcile.Result,
+	err error) (reconcile.Result, error) {
+	reqLogger := requestInfo.Log.WithValues("request_namespace", requestInfo.Namespace,
+		"request_name", requestInfo.Name)
+	if statusChanged {
+		if errStatus := requestInfo.Client.Status().Update(ctx, requestInfo.Object); errStatus != nil {
+			reqLogger.Error(errStatus, "Error updating the status")
+			if err == nil {
+				return reconcile.Result{}, errStatus
+			}
+		}
+	}
+	if httpclient.IsPermanent(err) {
+		reqLogger.Error(err, "Permanent error while syncing to APIM, requeue after a delay",
+			"requeue_after", RequeueDurationForPermanentError)
+		return reconcile.Result{RequeueAfter: RequeueDurationForPermanentError}, nil
+	}
+	return result, err
+}
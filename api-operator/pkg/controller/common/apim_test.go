@@ -0,0 +1,132 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strings"
+	"testing"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if err := wso2v1alpha2.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	return s
+}
+
+func TestCheckAPIMEnabled(t *testing.T) {
+	app := &wso2v1alpha2.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "app"}}
+	recorder := record.NewFakeRecorder(1)
+	var cl client.Client = fake.NewFakeClientWithScheme(testScheme(t))
+	enabled, result, err := CheckAPIMEnabled(&cl, recorder, app)
+	if enabled || err == nil || result.RequeueAfter != RequeueDurationForConfigError {
+		t.Errorf("expected requeueing a missing controller config but was %v, %+v, %v", enabled, result, err)
+	}
+
+	controlConf := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: ControllerConfName},
+		Data:       map[string]string{DeployAPIMEnabledConst: "false"},
+	}
+	cl = fake.NewFakeClientWithScheme(testScheme(t), controlConf)
+	enabled, result, err = CheckAPIMEnabled(&cl, recorder, app)
+	if enabled || err != nil || result.Requeue || result.RequeueAfter != 0 {
+		t.Errorf("expected no error nor requeueing when deploying to APIM is disabled but was %v, %+v, %v",
+			enabled, result, err)
+	}
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, reasonAPIMDisabled) {
+			t.Errorf("expected an event that deploying to APIM is disabled but was %q", e)
+		}
+	default:
+		t.Error("expected an event that deploying to APIM is disabled")
+	}
+
+	controlConf.Data[DeployAPIMEnabledConst] = "true"
+	cl = fake.NewFakeClientWithScheme(testScheme(t), controlConf)
+	enabled, result, err = CheckAPIMEnabled(&cl, recorder, app)
+	if !enabled || err != nil || result.Requeue || result.RequeueAfter != 0 {
+		t.Errorf("expected no error when deploying to APIM is enabled but was %v, %+v, %v", enabled, result, err)
+	}
+}
+
+func TestControllerConfigMapper(t *testing.T) {
+	controlConf := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: ControllerConfName},
+	}
+	cl := fake.NewFakeClientWithScheme(testScheme(t), controlConf,
+		&wso2v1alpha2.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "app"}},
+		&wso2v1alpha2.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "app"}})
+	mapper := &controllerConfigMapper{client: cl, list: &wso2v1alpha2.ApplicationList{}}
+
+	requests := mapper.Map(handler.MapObject{Meta: controlConf, Object: controlConf})
+	if len(requests) != 2 {
+		t.Errorf("expected requeueing all the applications but was %v", requests)
+	}
+	otherConf := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: "other"}}
+	if requests := mapper.Map(handler.MapObject{Meta: otherConf, Object: otherConf}); len(requests) != 0 {
+		t.Errorf("expected no requests for another configmap but was %v", requests)
+	}
+}
+
+func TestCompleteSync(t *testing.T) {
+	app := &wso2v1alpha2.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "app"}}
+	cl := fake.NewFakeClientWithScheme(testScheme(t), app.DeepCopy())
+	key := types.NamespacedName{Namespace: "ns", Name: "app"}
+	if err := cl.Get(context.TODO(), key, app); err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	requestInfo := &RequestInfo{Request: reconcile.Request{NamespacedName: key}, Client: cl, Object: app,
+		Log: logf.Log.WithName("test")}
+
+	errSync := &httpclient.Error{Op: "create application", StatusCode: 400, Status: "400 Bad Request"}
+	app.Status.LastError = errSync.Error()
+	result, err := CompleteSync(context.TODO(), requestInfo, true, reconcile.Result{}, errSync)
+	if err != nil || result.RequeueAfter != RequeueDurationForPermanentError {
+		t.Errorf("expected requeueing a permanent error after a delay but was %+v, %v", result, err)
+	}
+	updated := &wso2v1alpha2.Application{}
+	if err := cl.Get(context.TODO(), key, updated); err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if updated.Status.LastError != errSync.Error() {
+		t.Errorf("expected the status to be updated but the last error was %q", updated.Status.LastError)
+	}
+
+	errSync.Transient = true
+	if _, err := CompleteSync(context.TODO(), requestInfo, false, reconcile.Result{}, errSync); err != errSync {
+		t.Errorf("expected a transient error to be returned but was %v", err)
+	}
+}
@@ -23,4 +23,7 @@ var (
 	// empty mean watch for all namespaces
 	WatchNamespace                = ""
 	RequeueDurationForConfigError = 10 * time.Second
+	// RequeueDurationForPermanentError is the duration to requeue a request failed with a permanent error
+	// (e.g. validation or authorization failures), which is not expected to be resolved by retrying immediately
+	RequeueDurationForPermanentError = 5 * time.Minute
 )
@@ -0,0 +1,182 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package operatorconfig
+
+import (
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strconv"
+)
+
+var log = logf.Log.WithName("operatorconfig.controller")
+
+// Add creates a new Operator Config Controller and adds it to the Manager. The controller watches the configmaps and
+// secrets configuring the connections to APIM and the MGW Adapter, and reloads them when they are changed.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) *ReconcileOperatorConfig {
+	return &ReconcileOperatorConfig{
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor("operatorconfig-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("operatorconfig-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	mapper := &handler.EnqueueRequestsFromMapFunc{ToRequests: &configToTargetsMapper{client: mgr.GetClient()}}
+	if err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, mapper, dataChangedPredicate); err != nil {
+		return err
+	}
+	if err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, mapper, dataChangedPredicate); err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileOperatorConfig{}
+
+// ReconcileOperatorConfig reloads the configs of APIM and the MGW Adapter
+type ReconcileOperatorConfig struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+// Reconcile discards the cached tokens and HTTP clients of the target in the request and validates the
+// connectivity with the new configs if the target is enabled. The result is reported as an event of the controller configmap.
+func (r *ReconcileOperatorConfig) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("target", request.Name)
+	reqLogger.Info("Reloading configs")
+
+	controlConf := k8s.NewConfMap()
+	err := k8s.Get(&r.client, types.NamespacedName{Namespace: config.SystemNamespace, Name: common.ControllerConfName},
+		controlConf)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{RequeueAfter: common.RequeueDurationForConfigError}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	var targetName, enabledConst string
+	var invalidate func()
+	var reload func(client *client.Client) error
+	switch request.Name {
+	case targetAPIM:
+		targetName, enabledConst = "APIM", common.DeployAPIMEnabledConst
+		invalidate, reload = apim.InvalidateCaches, apim.ReloadConfigs
+	case targetMgwAdapter:
+		targetName, enabledConst = "MGW Adapter", deployAPIToMGWEnabledConst
+		invalidate, reload = envoy.InvalidateCaches, envoy.ReloadConfigs
+	default:
+		return reconcile.Result{}, nil
+	}
+
+	// the caches of a disabled target are discarded too, so that the changed configs are used once it is enabled.
+	// Validating the connectivity of a disabled target only reports false failures.
+	if enabled, _ := strconv.ParseBool(controlConf.Data[enabledConst]); !enabled {
+		invalidate()
+		reqLogger.Info("Deploying APIs is disabled, discarded the cached configs without validating them")
+		return reconcile.Result{}, nil
+	}
+
+	if err := reload(&r.client); err != nil {
+		reqLogger.Error(err, "Error validating the reloaded configs",
+			"requeue_after", common.RequeueDurationForPermanentError)
+		r.recorder.Event(controlConf, corev1.EventTypeWarning, reasonConfigsReloadFailed,
+			fmt.Sprintf("Reloaded the configs of %s, but validating the connectivity failed: %v", targetName, err))
+		return reconcile.Result{RequeueAfter: common.RequeueDurationForPermanentError}, nil
+	}
+
+	reqLogger.Info("Reloaded and validated configs")
+	r.recorder.Event(controlConf, corev1.EventTypeNormal, reasonConfigsReloaded,
+		fmt.Sprintf("Reloaded the configs of %s and validated the connectivity", targetName))
+	return reconcile.Result{}, nil
+}
+
+// configToTargetsMapper maps a configmap or secret in the system namespace to the reconcile requests of the
+// targets configured by it
+type configToTargetsMapper struct {
+	client client.Client
+}
+
+// Map implements handler.Mapper
+func (m *configToTargetsMapper) Map(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta.GetNamespace() != config.SystemNamespace {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	addRequest := func(target string, names []string) {
+		for _, name := range names {
+			if name == obj.Meta.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: config.SystemNamespace, Name: target},
+				})
+				return
+			}
+		}
+	}
+	addRequest(targetAPIM, apim.ConfigObjectNames(&m.client))
+	addRequest(targetMgwAdapter, envoy.ConfigObjectNames(&m.client))
+
+	if len(requests) > 0 {
+		log.Info("Operator config is changed", "name", obj.Meta.GetName(), "target_count", len(requests))
+	}
+	return requests
+}
+
+// dataChangedPredicate filters out update events of configmaps and secrets that do not change their data
+var dataChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		switch oldObj := e.ObjectOld.(type) {
+		case *corev1.ConfigMap:
+			newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+			return !ok || !reflect.DeepEqual(oldObj.Data, newObj.Data) ||
+				!reflect.DeepEqual(oldObj.BinaryData, newObj.BinaryData)
+		case *corev1.Secret:
+			newObj, ok := e.ObjectNew.(*corev1.Secret)
+			return !ok || !reflect.DeepEqual(oldObj.Data, newObj.Data)
+		}
+		return true
+	},
+}
@@ -0,0 +1,26 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package subscription
+
+const (
+	finalizerName = "wso2.com/subscription.finalizer"
+
+	// apiNameIndexKey is the field index of Subscriptions by the name of the API referred by them
+	apiNameIndexKey = "spec.apiName"
+	// applicationNameIndexKey is the field index of Subscriptions by the name of the Application referred by them
+	applicationNameIndexKey = "spec.applicationName"
+)
@@ -0,0 +1,277 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("subscription.controller")
+
+// Add creates a new Subscription Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileSubscription{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("subscription-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("subscription-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource Subscription
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.Subscription{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Index Subscriptions by the APIs and Applications referred by them
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.Subscription{}, apiNameIndexKey,
+		func(obj runtime.Object) []string {
+			return []string{obj.(*wso2v1alpha2.Subscription).Spec.APIName}
+		})
+	if err != nil {
+		return err
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &wso2v1alpha2.Subscription{}, applicationNameIndexKey,
+		func(obj runtime.Object) []string {
+			return []string{obj.(*wso2v1alpha2.Subscription).Spec.ApplicationName}
+		})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to APIs and Applications and requeue the Subscriptions referring them, as the IDs of them in
+	// APIM are resolved after they are synced
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.API{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &referenceToSubscriptionsMapper{client: mgr.GetClient(), indexKey: apiNameIndexKey},
+	})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &wso2v1alpha2.Application{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &referenceToSubscriptionsMapper{client: mgr.GetClient(), indexKey: applicationNameIndexKey},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the controller configs and requeue all the Subscriptions, as they are synced to APIM only if
+	// deploying to APIM is enabled
+	err = common.WatchAPIMEnabled(c, mgr.GetClient(), &wso2v1alpha2.SubscriptionList{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileSubscription{}
+
+// ReconcileSubscription reconciles a Subscription object
+type ReconcileSubscription struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile subscribes the Application to the API in APIM
+func (r *ReconcileSubscription) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("request_namespace", request.Namespace, "request_name", request.Name)
+	reqLogger.Info("Reconciling Subscription")
+
+	instance := &wso2v1alpha2.Subscription{}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	requestInfo := &common.RequestInfo{Request: request, Client: r.client, Object: instance, Log: log,
+		EvnRecorder: r.recorder}
+
+	err := k8s.Get(&r.client, request.NamespacedName, instance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Handle deletion with finalizers
+	if _, finUpdated, err := k8s.HandleObjectDeletion(ctx, requestInfo, finalizerName, func() error {
+		return r.finalizeDeletion(instance)
+	}); finUpdated || err != nil {
+		return reconcile.Result{}, err
+	}
+
+	oldStatus := instance.Status.DeepCopy()
+	instance.Status.LastError = ""
+	result, err := r.syncSubscription(instance, oldStatus)
+	if err != nil {
+		instance.Status.LastError = err.Error()
+		r.recorder.Event(instance, corev1.EventTypeWarning, "FailedSubscriptionSync",
+			"Error occurred while syncing the subscription to APIM")
+	}
+	instance.Status.ObservedGeneration = instance.Generation
+	return common.CompleteSync(ctx, requestInfo, !reflect.DeepEqual(oldStatus, &instance.Status), result, err)
+}
+
+// syncSubscription subscribes the Application to the API in APIM once both of them are synced to APIM. Syncing is
+// skipped if neither the Subscription nor the IDs of its API and Application are changed since the last successful
+// sync recorded in the old status.
+func (r *ReconcileSubscription) syncSubscription(instance *wso2v1alpha2.Subscription,
+	oldStatus *wso2v1alpha2.SubscriptionStatus) (reconcile.Result, error) {
+	if enabled, result, err := common.CheckAPIMEnabled(&r.client, r.recorder, instance); !enabled {
+		return result, err
+	}
+
+	// the subscription is synced when the API or the Application is synced, as they are watched
+	api := &wso2v1alpha2.API{}
+	if err := k8s.Get(&r.client, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.APIName},
+		api); err != nil {
+		instance.Status.LastError = fmt.Sprintf("waiting for the API %q: %v", instance.Spec.APIName, err)
+		return reconcile.Result{}, ignoreNotFound(err)
+	}
+	if api.Status.APIMID == "" {
+		instance.Status.LastError = fmt.Sprintf("waiting for the API %q to be imported to APIM",
+			instance.Spec.APIName)
+		return reconcile.Result{}, nil
+	}
+	app := &wso2v1alpha2.Application{}
+	if err := k8s.Get(&r.client, types.NamespacedName{Namespace: instance.Namespace,
+		Name: instance.Spec.ApplicationName}, app); err != nil {
+		instance.Status.LastError = fmt.Sprintf("waiting for the Application %q: %v", instance.Spec.ApplicationName,
+			err)
+		return reconcile.Result{}, ignoreNotFound(err)
+	}
+	if app.Status.ApplicationID == "" {
+		instance.Status.LastError = fmt.Sprintf("waiting for the Application %q to be synced to APIM",
+			instance.Spec.ApplicationName)
+		return reconcile.Result{}, nil
+	}
+
+	if oldStatus.ObservedGeneration == instance.Generation && oldStatus.LastError == "" &&
+		oldStatus.SubscriptionID != "" && oldStatus.APIMID == api.Status.APIMID &&
+		oldStatus.ApplicationID == app.Status.ApplicationID {
+		return reconcile.Result{}, nil
+	}
+
+	subscription, err := apim.SyncSubscription(&r.client, api.Status.APIMID, app.Status.ApplicationID,
+		instance.Spec.ThrottlingTier)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// delete the previous subscription if the API or the Application of the Subscription is changed
+	if oldId := instance.Status.SubscriptionID; oldId != "" && oldId != subscription.SubscriptionID {
+		if err := apim.DeleteSubscription(&r.client, oldId); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	instance.Status.SubscriptionID = subscription.SubscriptionID
+	instance.Status.APIMID = api.Status.APIMID
+	instance.Status.ApplicationID = app.Status.ApplicationID
+	instance.Status.Status = subscription.Status
+
+	log.Info("Successfully synced the subscription to APIM", "namespace", instance.Namespace,
+		"name", instance.Name, "subscription_id", subscription.SubscriptionID)
+	r.recorder.Event(instance, corev1.EventTypeNormal, "SubscriptionSync",
+		fmt.Sprintf("Successfully subscribed the application %s to the API %s", instance.Spec.ApplicationName,
+			instance.Spec.APIName))
+	return reconcile.Result{}, nil
+}
+
+// finalizeDeletion deletes the subscription from APIM
+func (r *ReconcileSubscription) finalizeDeletion(instance *wso2v1alpha2.Subscription) error {
+	if instance.Status.SubscriptionID == "" {
+		return nil
+	}
+	enabled, err := common.IsAPIMEnabled(&r.client)
+	if err != nil || !enabled {
+		return err
+	}
+	if err := apim.DeleteSubscription(&r.client, instance.Status.SubscriptionID); err != nil {
+		return err
+	}
+	log.Info("Successfully deleted the subscription from APIM", "namespace", instance.Namespace,
+		"name", instance.Name)
+	return nil
+}
+
+// referenceToSubscriptionsMapper maps an API or Application to the reconcile requests of the Subscriptions
+// referring it
+type referenceToSubscriptionsMapper struct {
+	client   client.Client
+	indexKey string
+}
+
+// Map implements handler.Mapper
+func (m *referenceToSubscriptionsMapper) Map(obj handler.MapObject) []reconcile.Request {
+	subscriptionList := &wso2v1alpha2.SubscriptionList{}
+	err := m.client.List(context.TODO(), subscriptionList, client.InNamespace(obj.Meta.GetNamespace()),
+		client.MatchingFields{m.indexKey: obj.Meta.GetName()})
+	if err != nil {
+		log.Error(err, "Error listing Subscriptions referring the object", "namespace", obj.Meta.GetNamespace(),
+			"name", obj.Meta.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(subscriptionList.Items))
+	for _, subscription := range subscriptionList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: subscription.Namespace, Name: subscription.Name},
+		})
+	}
+	return requests
+}
+
+func ignoreNotFound(err error) error {
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -81,6 +81,15 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the deployments of TargetEndpoints to report their available replicas
+	err = c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &wso2v1alpha2.TargetEndpoint{},
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -204,9 +213,35 @@ func (r *ReconcileTargetEndpoint) Reconcile(request reconcile.Request) (reconcil
 		}
 	}
 
+	if err := r.updateStatus(instance, mode); err != nil {
+		reqLogger.Error(err, "Error updating the status of the TargetEndpoint")
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{}, nil
 }
 
+// updateStatus sets the available replicas of the deployment of the TargetEndpoint and the observed generation
+// and updates the status sub-resource if it is changed
+func (r *ReconcileTargetEndpoint) updateStatus(m *wso2v1alpha2.TargetEndpoint, mode string) error {
+	status := wso2v1alpha2.TargetEndpointStatus{ObservedGeneration: m.Generation}
+	if strings.EqualFold(mode, privateJet) {
+		dep := &appsv1.Deployment{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: m.Name, Namespace: m.Namespace}, dep)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			status.AvailableReplicas = dep.Status.AvailableReplicas
+		}
+	}
+
+	if reflect.DeepEqual(status, m.Status) {
+		return nil
+	}
+	m.Status = status
+	return r.client.Status().Update(context.TODO(), m)
+}
+
 // Create newDeploymentForCR method to create a deployment.
 func (r *ReconcileTargetEndpoint) newDeploymentForCR(m *wso2v1alpha2.TargetEndpoint, resourceReqCPU string, resourceReqMemory string,
 	resourceLimitCPU string, resourceLimitMemory string, minReplicas int32) *appsv1.Deployment {
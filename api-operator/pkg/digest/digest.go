@@ -0,0 +1,76 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package digest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	corev1 "k8s.io/api/core/v1"
+	"sort"
+)
+
+const prefix = "sha256:"
+
+// ConfigMaps returns a deterministic digest of the data of the given configmaps. The order of the configmaps is
+// significant and a nil configmap is treated as an absent configmap.
+func ConfigMaps(configMaps ...*corev1.ConfigMap) string {
+	h := sha256.New()
+	for _, cm := range configMaps {
+		if cm == nil {
+			writeField(h, []byte{0})
+			continue
+		}
+		writeField(h, []byte{1})
+
+		keys := make([]string, 0, len(cm.Data))
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		writeLength(h, len(keys))
+		for _, key := range keys {
+			writeField(h, []byte(key))
+			writeField(h, []byte(cm.Data[key]))
+		}
+
+		binaryKeys := make([]string, 0, len(cm.BinaryData))
+		for key := range cm.BinaryData {
+			binaryKeys = append(binaryKeys, key)
+		}
+		sort.Strings(binaryKeys)
+		writeLength(h, len(binaryKeys))
+		for _, key := range binaryKeys {
+			writeField(h, []byte(key))
+			writeField(h, cm.BinaryData[key])
+		}
+	}
+	return prefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// writeField writes the length prefixed value, so that adjacent values can not be confused with each other
+func writeField(h hash.Hash, value []byte) {
+	writeLength(h, len(value))
+	h.Write(value)
+}
+
+func writeLength(h hash.Hash, length int) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(length))
+	h.Write(buf[:])
+}
@@ -0,0 +1,59 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package digest
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+	"testing"
+)
+
+func TestConfigMaps(t *testing.T) {
+	swagger := &corev1.ConfigMap{Data: map[string]string{"swagger.yaml": "openapi: 3.0.0"}}
+	params := &corev1.ConfigMap{Data: map[string]string{"params.yaml": "environments: []", "other": "value"}}
+
+	d1 := ConfigMaps(swagger, params, nil)
+	if !strings.HasPrefix(d1, "sha256:") {
+		t.Errorf("digest should be prefixed with the algorithm but was: %s", d1)
+	}
+	for i := 0; i < 10; i++ {
+		if d := ConfigMaps(swagger, params, nil); d != d1 {
+			t.Errorf("digest of the same configmaps should be the same, want: %s but was: %s", d1, d)
+		}
+	}
+
+	if d := ConfigMaps(swagger, nil, params); d == d1 {
+		t.Error("digest should depend on the order of the configmaps")
+	}
+
+	changed := &corev1.ConfigMap{Data: map[string]string{"swagger.yaml": "openapi: 3.0.1"}}
+	if d := ConfigMaps(changed, params, nil); d == d1 {
+		t.Error("digest should change when the data of a configmap is changed")
+	}
+
+	binary := &corev1.ConfigMap{BinaryData: map[string][]byte{"swagger.yaml": []byte("openapi: 3.0.0")}}
+	if d := ConfigMaps(binary, params, nil); d == d1 {
+		t.Error("digest should differ for binary data and string data")
+	}
+
+	// values should not be confused with the adjacent keys
+	a := &corev1.ConfigMap{Data: map[string]string{"ab": "c"}}
+	b := &corev1.ConfigMap{Data: map[string]string{"a": "bc"}}
+	if ConfigMaps(a) == ConfigMaps(b) {
+		t.Error("digest should differ when the boundary of a key and value is changed")
+	}
+}
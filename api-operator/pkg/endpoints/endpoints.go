@@ -0,0 +1,358 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package endpoints
+
+import (
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/swagger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+const (
+	protocolHTTP  = "http"
+	protocolHTTPS = "https"
+)
+
+const (
+	kindTargetEndpoint = "target endpoint"
+	kindEndpointGroup  = "endpoint group"
+)
+
+// UnresolvedError is an endpoint of an API referring a TargetEndpoint or an EndpointGroup which can not be resolved
+type UnresolvedError struct {
+	// Kind of the referred resource, "target endpoint" or "endpoint group"
+	Kind string
+	// Ref is the reference to the TargetEndpoint or the EndpointGroup
+	Ref string
+	// Message describes why the reference can not be resolved
+	Message string
+}
+
+func (e *UnresolvedError) Error() string {
+	return fmt.Sprintf("unable to resolve the %s %q: %s", e.Kind, e.Ref, e.Message)
+}
+
+// IsUnresolved returns true if the given error is an UnresolvedError
+func IsUnresolved(err error) bool {
+	_, ok := err.(*UnresolvedError)
+	return ok
+}
+
+// IsUnresolvedEndpointGroup returns true if the given error is an UnresolvedError of an EndpointGroup
+func IsUnresolvedEndpointGroup(err error) bool {
+	unresolvedErr, ok := err.(*UnresolvedError)
+	return ok && unresolvedErr.Kind == kindEndpointGroup
+}
+
+// UnavailableError is TargetEndpoints and EndpointGroups referred by an API which have no available replicas
+type UnavailableError struct {
+	// TargetEndpoints without available replicas as "<namespace>/<name>"
+	TargetEndpoints []string
+	// EndpointGroups without any available member as "<namespace>/<name>"
+	EndpointGroups []string
+}
+
+func (e *UnavailableError) Error() string {
+	backends := append([]string{}, e.TargetEndpoints...)
+	for _, group := range e.EndpointGroups {
+		backends = append(backends, "members of the endpoint group "+group)
+	}
+	return fmt.Sprintf("waiting for the target endpoints to have available replicas: %s",
+		strings.Join(backends, ", "))
+}
+
+// IsUnavailable returns true if the given error is an UnavailableError
+func IsUnavailable(err error) bool {
+	_, ok := err.(*UnavailableError)
+	return ok
+}
+
+// Backends are the TargetEndpoints and the EndpointGroups referred by the endpoints of an API
+type Backends struct {
+	// TargetEndpoints referred directly by the endpoints
+	TargetEndpoints []*wso2v1alpha2.TargetEndpoint
+	// EndpointGroups referred by the endpoints
+	EndpointGroups []*wso2v1alpha2.EndpointGroup
+	// members are the TargetEndpoints in the EndpointGroups keyed by "<namespace>/<name>" of the groups
+	members map[string][]*wso2v1alpha2.TargetEndpoint
+}
+
+// Empty returns true if no TargetEndpoint or EndpointGroup is referred
+func (b *Backends) Empty() bool {
+	return b == nil || (len(b.TargetEndpoints) == 0 && len(b.EndpointGroups) == 0)
+}
+
+// CheckAvailable returns an UnavailableError if any of the given TargetEndpoints has no available replicas, or any
+// of the given EndpointGroups has neither an external URL nor a TargetEndpoint with available replicas.
+// TargetEndpoints which are not deployed as deployments, in "serverless" and "sidecar" modes or without a docker
+// image, are available.
+func CheckAvailable(backends *Backends) error {
+	if backends == nil {
+		return nil
+	}
+	var unavailable, unavailableGroups []string
+	for _, targetEndpoint := range backends.TargetEndpoints {
+		if !isAvailable(targetEndpoint) {
+			unavailable = append(unavailable, targetEndpoint.Namespace+"/"+targetEndpoint.Name)
+		}
+	}
+	for _, group := range backends.EndpointGroups {
+		groupName := group.Namespace + "/" + group.Name
+		if !isGroupAvailable(group, backends.members[groupName]) {
+			unavailableGroups = append(unavailableGroups, groupName)
+		}
+	}
+	if len(unavailable) != 0 || len(unavailableGroups) != 0 {
+		return &UnavailableError{TargetEndpoints: unavailable, EndpointGroups: unavailableGroups}
+	}
+	return nil
+}
+
+// References returns the TargetEndpoints and the EndpointGroups referred by the endpoints in the swagger of the
+// given configmap of the given API without duplicates. A project zip has no references.
+func References(api *wso2v1alpha2.API, swaggerCM *corev1.ConfigMap) (targetEndpoints,
+	endpointGroups []types.NamespacedName, err error) {
+	if swaggerCM.BinaryData != nil {
+		return nil, nil, nil
+	}
+	swaggerFileName, err := maps.OneKey(swaggerCM.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	refs, groups, err := swagger.EndpointRefs(swaggerCM.Data[swaggerFileName])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targetEndpoints, endpointGroups = referenceNames(api, refs, groups)
+	return targetEndpoints, endpointGroups, nil
+}
+
+// referenceNames returns the names of the given TargetEndpoints and EndpointGroups referred by the given API without
+// duplicates
+func referenceNames(api *wso2v1alpha2.API, refs []*swagger.TargetEndpointRef, groups []string) (targetEndpoints,
+	endpointGroups []types.NamespacedName) {
+	found := make(map[types.NamespacedName]bool, len(refs))
+	for _, ref := range refs {
+		name := namespacedName(api, ref)
+		if !found[name] {
+			found[name] = true
+			targetEndpoints = append(targetEndpoints, name)
+		}
+	}
+	found = make(map[types.NamespacedName]bool, len(groups))
+	for _, group := range groups {
+		name := types.NamespacedName{Namespace: api.Namespace, Name: group}
+		if !found[name] {
+			found[name] = true
+			endpointGroups = append(endpointGroups, name)
+		}
+	}
+	return targetEndpoints, endpointGroups
+}
+
+// ResolveSwagger returns a copy of the given swagger configmap of the given API with the endpoints referring
+// TargetEndpoints replaced with the URLs of the services of the TargetEndpoints and the endpoints referring
+// EndpointGroups replaced with the URLs of their members, and the referred TargetEndpoints and EndpointGroups.
+// The configmap is returned as it is if it is a project zip or no endpoint refers a TargetEndpoint or an
+// EndpointGroup. The returned error is an UnresolvedError if a referred TargetEndpoint or EndpointGroup is not
+// found or is invalid.
+func ResolveSwagger(client *client.Client, api *wso2v1alpha2.API, swaggerCM *corev1.ConfigMap) (*corev1.ConfigMap,
+	*Backends, error) {
+	if swaggerCM.BinaryData != nil {
+		return swaggerCM, nil, nil
+	}
+	swaggerFileName, err := maps.OneKey(swaggerCM.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := newResolver(client, api)
+	resolved, err := swagger.ResolveEndpoints(swaggerCM.Data[swaggerFileName], r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.backends.Empty() {
+		return swaggerCM, nil, nil
+	}
+
+	resolvedCM := swaggerCM.DeepCopy()
+	resolvedCM.Data[swaggerFileName] = resolved
+	return resolvedCM, r.backends, nil
+}
+
+// ServiceURL returns the URL of the service of the given TargetEndpoint with the given port, or with the first port
+// of the TargetEndpoint if the given port is zero
+func ServiceURL(targetEndpoint *wso2v1alpha2.TargetEndpoint, port int32) (string, error) {
+	protocol := targetEndpoint.Spec.ApplicationProtocol
+	if protocol == "" {
+		protocol = protocolHTTP
+	}
+	if protocol != protocolHTTP && protocol != protocolHTTPS {
+		return "", fmt.Errorf("unsupported application protocol %q", protocol)
+	}
+
+	if port == 0 {
+		if len(targetEndpoint.Spec.Ports) == 0 {
+			return "", fmt.Errorf("target endpoint has no ports")
+		}
+		// the service port of the first port is defaulted by the protocol as in the service of the TargetEndpoint
+		port = targetEndpoint.Spec.Ports[0].Port
+		if port == 0 && protocol == protocolHTTPS {
+			port = 443
+		} else if port == 0 {
+			port = 80
+		}
+	} else if !hasPort(targetEndpoint, port) {
+		return "", fmt.Errorf("target endpoint has no port %d", port)
+	}
+	return fmt.Sprintf("%s://%s.%s:%d", protocol, targetEndpoint.Name, targetEndpoint.Namespace, port), nil
+}
+
+// EndpointSecurity is the endpoint security of the production and the sandbox endpoints of an API
+type EndpointSecurity struct {
+	// Production is the security of the production endpoints. Nil if the production endpoints are not secured.
+	Production *wso2v1alpha2.EndpointSecurity
+	// Sandbox is the security of the sandbox endpoints. Nil if the sandbox endpoints are not secured.
+	Sandbox *wso2v1alpha2.EndpointSecurity
+}
+
+func (s *EndpointSecurity) String() string {
+	format := func(security *wso2v1alpha2.EndpointSecurity) string {
+		if security == nil {
+			return "<nil>"
+		}
+		return fmt.Sprintf("%+v", *security)
+	}
+	return fmt.Sprintf("production: %s, sandbox: %s", format(s.Production), format(s.Sandbox))
+}
+
+// Security returns the endpoint security of the production and the sandbox endpoints of the given API, or nil if
+// neither of them is secured. The security of the production or the sandbox endpoints is the one of the first
+// TargetEndpoint referred by them, directly or as a member of an EndpointGroup, which is in the namespace of the
+// API and has an endpoint security. Endpoint securities of the TargetEndpoints in other namespaces are not used, as
+// their secrets belong to other namespaces.
+func Security(client *client.Client, api *wso2v1alpha2.API) (*EndpointSecurity, error) {
+	swaggerCM := k8s.NewConfMap()
+	if err := k8s.Get(client, types.NamespacedName{Namespace: api.Namespace, Name: api.Spec.SwaggerConfigMapName},
+		swaggerCM); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if swaggerCM.BinaryData != nil {
+		return nil, nil
+	}
+	swaggerFileName, err := maps.OneKey(swaggerCM.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	definition := swaggerCM.Data[swaggerFileName]
+	production, err := extensionSecurity(client, api, definition, swagger.ProductionEndpointsExtension)
+	if err != nil {
+		return nil, err
+	}
+	sandbox, err := extensionSecurity(client, api, definition, swagger.SandboxEndpointsExtension)
+	if err != nil {
+		return nil, err
+	}
+	if production == nil && sandbox == nil {
+		return nil, nil
+	}
+	return &EndpointSecurity{Production: production, Sandbox: sandbox}, nil
+}
+
+// extensionSecurity returns the endpoint security of the first TargetEndpoint referred by the endpoints of the given
+// extension in the given API definition, directly or as a member of an EndpointGroup, which is in the namespace of
+// the API and has an endpoint security
+func extensionSecurity(client *client.Client, api *wso2v1alpha2.API, definition, extension string) (
+	*wso2v1alpha2.EndpointSecurity, error) {
+	refs, groups, err := swagger.EndpointRefsOf(definition, extension)
+	if err != nil {
+		return nil, err
+	}
+	names, groupNames := referenceNames(api, refs, groups)
+	for _, groupName := range groupNames {
+		group := &wso2v1alpha2.EndpointGroup{}
+		if err := k8s.Get(client, groupName, group); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, member := range group.Spec.Members {
+			if member.TargetEndpoint != "" {
+				names = append(names, types.NamespacedName{Namespace: group.Namespace, Name: member.TargetEndpoint})
+			}
+		}
+	}
+
+	for _, name := range names {
+		if name.Namespace != api.Namespace {
+			continue
+		}
+		targetEndpoint := &wso2v1alpha2.TargetEndpoint{}
+		if err := k8s.Get(client, name, targetEndpoint); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if targetEndpoint.Spec.EndpointSecurity != nil {
+			return targetEndpoint.Spec.EndpointSecurity, nil
+		}
+	}
+	return nil, nil
+}
+
+// isAvailable returns true if the given TargetEndpoint has available replicas or is not deployed as a deployment.
+// A TargetEndpoint without a docker image has no managed deployment, hence its replicas are not checked.
+func isAvailable(targetEndpoint *wso2v1alpha2.TargetEndpoint) bool {
+	mode := targetEndpoint.Spec.Mode
+	if mode != "" && !strings.EqualFold(mode.String(), wso2v1alpha2.PrivateJet.String()) {
+		return true
+	}
+	if targetEndpoint.Spec.Deploy.DockerImage == "" {
+		return true
+	}
+	return targetEndpoint.Status.AvailableReplicas > 0
+}
+
+func hasPort(targetEndpoint *wso2v1alpha2.TargetEndpoint, port int32) bool {
+	for _, p := range targetEndpoint.Spec.Ports {
+		if p.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
+func namespacedName(api *wso2v1alpha2.API, ref *swagger.TargetEndpointRef) types.NamespacedName {
+	if ref.Namespace == "" {
+		return types.NamespacedName{Namespace: api.Namespace, Name: ref.Name}
+	}
+	return types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+}
@@ -0,0 +1,299 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package endpoints
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+const testSwagger = `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-production-endpoints:
+  targetEndpoint: products
+x-wso2-sandbox-endpoints:
+  urls:
+    - k8s://backends/products:8443
+paths:
+  /products:
+    get:
+      x-wso2-production-endpoints:
+        targetEndpoint: products
+      responses:
+        '200':
+          description: OK
+`
+
+const testGroupSwagger = `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-production-endpoints:
+  endpointGroup: products
+paths:
+  /products:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func newTargetEndpoint(namespace, name, protocol string, ports ...int32) *wso2v1alpha2.TargetEndpoint {
+	targetEndpoint := &wso2v1alpha2.TargetEndpoint{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	targetEndpoint.Spec.ApplicationProtocol = protocol
+	for _, port := range ports {
+		targetEndpoint.Spec.Ports = append(targetEndpoint.Spec.Ports, wso2v1alpha2.Port{Name: "port", Port: port})
+	}
+	return targetEndpoint
+}
+
+func newEndpointGroup(groupType wso2v1alpha2.EndpointGroupType, algorithm wso2v1alpha2.LoadBalanceAlgorithm,
+	members ...wso2v1alpha2.EndpointGroupMember) *wso2v1alpha2.EndpointGroup {
+	group := &wso2v1alpha2.EndpointGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "products"}}
+	group.Spec.Type, group.Spec.Algorithm, group.Spec.Members = groupType, algorithm, members
+	return group
+}
+
+func newClient(objects ...runtime.Object) *client.Client {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(s)
+	var cl client.Client = fake.NewFakeClientWithScheme(s, objects...)
+	return &cl
+}
+
+func TestServiceURL(t *testing.T) {
+	tests := []struct {
+		targetEndpoint *wso2v1alpha2.TargetEndpoint
+		port           int32
+		url            string
+		wantErr        bool
+	}{
+		{targetEndpoint: newTargetEndpoint("ns", "products", "http", 8080, 9090),
+			url: "http://products.ns:8080"},
+		{targetEndpoint: newTargetEndpoint("ns", "products", "http", 8080, 9090), port: 9090,
+			url: "http://products.ns:9090"},
+		{targetEndpoint: newTargetEndpoint("ns", "products", "https", 0), url: "https://products.ns:443"},
+		{targetEndpoint: newTargetEndpoint("ns", "products", "", 0), url: "http://products.ns:80"},
+		{targetEndpoint: newTargetEndpoint("ns", "products", "http", 8080), port: 9090, wantErr: true},
+		{targetEndpoint: newTargetEndpoint("ns", "products", "http"), wantErr: true},
+		{targetEndpoint: newTargetEndpoint("ns", "products", "grpc", 8080), wantErr: true},
+	}
+
+	for _, test := range tests {
+		url, err := ServiceURL(test.targetEndpoint, test.port)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("expected an error for %+v but the URL was %q", test.targetEndpoint.Spec, url)
+			}
+			continue
+		}
+		if err != nil || url != test.url {
+			t.Errorf("expected the URL %q but was %q, %v", test.url, url, err)
+		}
+	}
+}
+
+func TestResolveSwagger(t *testing.T) {
+	swaggerCM := k8s.NewConfMap()
+	swaggerCM.Namespace, swaggerCM.Name = "ns", "products-swagger"
+	swaggerCM.Data = map[string]string{"swagger.yaml": testSwagger}
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "products"}}
+	api.Spec.SwaggerConfigMapName = swaggerCM.Name
+
+	names, groupNames, err := References(api, swaggerCM)
+	if err != nil || len(groupNames) != 0 || len(names) != 2 || names[0].String() != "ns/products" ||
+		names[1].String() != "backends/products" {
+		t.Errorf("expected the referred target endpoints without duplicates but was %v, %v", names, err)
+	}
+
+	cl := newClient(swaggerCM, newTargetEndpoint("ns", "products", "http", 8080))
+	_, _, err = ResolveSwagger(cl, api, swaggerCM)
+	if !IsUnresolved(err) || !strings.Contains(err.Error(), "backends/products:8443") {
+		t.Errorf("expected an unresolved error for the missing target endpoint but was %v", err)
+	}
+
+	securedEndpoint := newTargetEndpoint("ns", "products", "http", 8080)
+	securedEndpoint.Spec.EndpointSecurity = &wso2v1alpha2.EndpointSecurity{Type: "basic", SecretName: "products"}
+	cl = newClient(swaggerCM, securedEndpoint, newTargetEndpoint("backends", "products", "https", 8443))
+	resolvedCM, backends, err := ResolveSwagger(cl, api, swaggerCM)
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if len(backends.TargetEndpoints) != 2 {
+		t.Errorf("expected the referred target endpoints without duplicates but was %d",
+			len(backends.TargetEndpoints))
+	}
+	resolved := resolvedCM.Data["swagger.yaml"]
+	if strings.Contains(resolved, "targetEndpoint") || strings.Contains(resolved, "k8s://") ||
+		!strings.Contains(resolved, "http://products.ns:8080") ||
+		!strings.Contains(resolved, "https://products.backends:8443") {
+		t.Errorf("expected the endpoints resolved to the service URLs but was\n%s", resolved)
+	}
+	if swaggerCM.Data["swagger.yaml"] != testSwagger {
+		t.Error("expected the given configmap not to be changed")
+	}
+
+	security, err := Security(cl, api)
+	if err != nil || security == nil || security.Production == nil || security.Production.SecretName != "products" {
+		t.Errorf("expected the endpoint security of the production target endpoint but was %v, %v", security, err)
+	} else if security.Sandbox != nil {
+		t.Errorf("expected no endpoint security for the unsecured sandbox target endpoint but was %v", security)
+	}
+}
+
+func TestCheckAvailable(t *testing.T) {
+	available := newTargetEndpoint("ns", "available", "http", 8080)
+	available.Spec.Deploy.DockerImage = "products:1.0.0"
+	available.Status.AvailableReplicas = 1
+	unavailable := newTargetEndpoint("ns", "unavailable", "http", 8080)
+	unavailable.Spec.Deploy.DockerImage = "products:1.0.0"
+	serverless := newTargetEndpoint("ns", "serverless", "http", 8080)
+	serverless.Spec.Mode = wso2v1alpha2.Serverless
+	// no deployment is managed for a target endpoint without a docker image
+	external := newTargetEndpoint("ns", "external", "http", 8080)
+	external.Spec.Mode = wso2v1alpha2.PrivateJet
+
+	backends := &Backends{TargetEndpoints: []*wso2v1alpha2.TargetEndpoint{available, serverless, external,
+		newTargetEndpoint("ns", "default", "http", 8080)}}
+	if err := CheckAvailable(backends); err != nil {
+		t.Errorf("expected no error for available target endpoints but was %v", err)
+	}
+	backends.TargetEndpoints = append(backends.TargetEndpoints, unavailable)
+	err := CheckAvailable(backends)
+	if !IsUnavailable(err) || len(err.(*UnavailableError).TargetEndpoints) != 1 ||
+		!strings.Contains(err.Error(), "ns/unavailable") {
+		t.Errorf("expected an unavailable error naming the target endpoint without replicas but was %v", err)
+	}
+
+	group := newEndpointGroup(wso2v1alpha2.FailoverEndpointGroup, "", wso2v1alpha2.EndpointGroupMember{
+		TargetEndpoint: "unavailable"}, wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "available"})
+	backends = &Backends{EndpointGroups: []*wso2v1alpha2.EndpointGroup{group},
+		members: map[string][]*wso2v1alpha2.TargetEndpoint{"ns/products": {unavailable, available}}}
+	if err := CheckAvailable(backends); err != nil {
+		t.Errorf("expected no error for an endpoint group with an available member but was %v", err)
+	}
+	backends.members["ns/products"] = []*wso2v1alpha2.TargetEndpoint{unavailable}
+	err = CheckAvailable(backends)
+	if !IsUnavailable(err) || len(err.(*UnavailableError).EndpointGroups) != 1 ||
+		!strings.Contains(err.Error(), "ns/products") {
+		t.Errorf("expected an unavailable error naming the endpoint group without available members but was %v",
+			err)
+	}
+	group.Spec.Members = append(group.Spec.Members, wso2v1alpha2.EndpointGroupMember{URL: "https://dr.example.com"})
+	if err := CheckAvailable(backends); err != nil {
+		t.Errorf("expected no error for an endpoint group with an external member but was %v", err)
+	}
+}
+
+func TestResolveEndpointGroup(t *testing.T) {
+	swaggerCM := k8s.NewConfMap()
+	swaggerCM.Namespace, swaggerCM.Name = "ns", "products-swagger"
+	swaggerCM.Data = map[string]string{"swagger.yaml": testGroupSwagger}
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "products"}}
+	api.Spec.SwaggerConfigMapName = swaggerCM.Name
+
+	names, groupNames, err := References(api, swaggerCM)
+	if err != nil || len(names) != 0 || len(groupNames) != 1 || groupNames[0].String() != "ns/products" {
+		t.Errorf("expected the referred endpoint group but was %v, %v, %v", names, groupNames, err)
+	}
+
+	cl := newClient(swaggerCM)
+	if _, _, err = ResolveSwagger(cl, api, swaggerCM); !IsUnresolvedEndpointGroup(err) {
+		t.Errorf("expected an unresolved error for the missing endpoint group but was %v", err)
+	}
+
+	tests := []struct {
+		group *wso2v1alpha2.EndpointGroup
+		urls  string
+		typ   string
+	}{
+		{group: newEndpointGroup(wso2v1alpha2.FailoverEndpointGroup, "",
+			wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "blue"},
+			wso2v1alpha2.EndpointGroupMember{URL: "https://dr.example.com"}),
+			urls: "http://blue.ns:8080,https://dr.example.com", typ: "failover"},
+		{group: newEndpointGroup(wso2v1alpha2.LoadBalanceEndpointGroup, "",
+			wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "blue", Weight: 3},
+			wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "green", Port: 9090}),
+			urls: "http://blue.ns:8080,http://green.ns:9090", typ: "loadbalance"},
+		{group: newEndpointGroup(wso2v1alpha2.LoadBalanceEndpointGroup, wso2v1alpha2.Weighted,
+			wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "blue", Weight: 4},
+			wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "green", Port: 9090, Weight: 2}),
+			urls: "http://blue.ns:8080,http://green.ns:9090,http://blue.ns:8080", typ: "loadbalance"},
+		{group: newEndpointGroup(wso2v1alpha2.LoadBalanceEndpointGroup, wso2v1alpha2.Weighted,
+			wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "green"}),
+			urls: "http://green.ns:8080"},
+	}
+	for _, test := range tests {
+		cl = newClient(swaggerCM, test.group, newTargetEndpoint("ns", "blue", "http", 8080),
+			newTargetEndpoint("ns", "green", "http", 8080, 9090))
+		resolvedCM, backends, err := ResolveSwagger(cl, api, swaggerCM)
+		if err != nil {
+			t.Errorf("expected no error for %+v but was %v", test.group.Spec, err)
+			continue
+		}
+		if len(backends.EndpointGroups) != 1 || len(backends.TargetEndpoints) != 0 {
+			t.Errorf("expected only the referred endpoint group but was %+v", backends)
+		}
+		endpoints := struct {
+			Production struct {
+				URLs []string `json:"urls"`
+				Type string   `json:"type"`
+			} `json:"x-wso2-production-endpoints"`
+		}{}
+		if err := yaml.Unmarshal([]byte(resolvedCM.Data["swagger.yaml"]), &endpoints); err != nil {
+			t.Fatalf("expected a valid resolved swagger but was %v", err)
+		}
+		if urls := strings.Join(endpoints.Production.URLs, ","); urls != test.urls ||
+			endpoints.Production.Type != test.typ {
+			t.Errorf("expected the URLs %q of type %q for %+v but was %q of type %q", test.urls, test.typ,
+				test.group.Spec, urls, endpoints.Production.Type)
+		}
+	}
+
+	invalidGroup := newEndpointGroup(wso2v1alpha2.FailoverEndpointGroup, "",
+		wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "blue", URL: "https://dr.example.com"})
+	cl = newClient(swaggerCM, invalidGroup, newTargetEndpoint("ns", "blue", "http", 8080))
+	if _, _, err = ResolveSwagger(cl, api, swaggerCM); !IsUnresolvedEndpointGroup(err) {
+		t.Errorf("expected an unresolved error for the invalid member but was %v", err)
+	}
+
+	missingMember := newEndpointGroup(wso2v1alpha2.FailoverEndpointGroup, "",
+		wso2v1alpha2.EndpointGroupMember{TargetEndpoint: "blue"})
+	securedEndpoint := newTargetEndpoint("ns", "blue", "http", 8080)
+	securedEndpoint.Spec.EndpointSecurity = &wso2v1alpha2.EndpointSecurity{Type: "basic", SecretName: "blue"}
+	cl = newClient(swaggerCM, missingMember)
+	if _, _, err = ResolveSwagger(cl, api, swaggerCM); !IsUnresolved(err) ||
+		!strings.Contains(err.Error(), "blue") {
+		t.Errorf("expected an unresolved error naming the missing member but was %v", err)
+	}
+	cl = newClient(swaggerCM, missingMember, securedEndpoint)
+	security, err := Security(cl, api)
+	if err != nil || security == nil || security.Production == nil || security.Production.SecretName != "blue" {
+		t.Errorf("expected the endpoint security of the member target endpoint but was %v, %v", security, err)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package endpoints
+
+import (
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/swagger"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolver is a swagger.EndpointResolver resolving the TargetEndpoints and the EndpointGroups referred by an API.
+// Each TargetEndpoint and EndpointGroup is fetched once.
+type resolver struct {
+	client   *client.Client
+	api      *wso2v1alpha2.API
+	backends *Backends
+
+	// targetEndpoints are the fetched TargetEndpoints, referred directly or as members of EndpointGroups
+	targetEndpoints map[types.NamespacedName]*wso2v1alpha2.TargetEndpoint
+	// referred are the TargetEndpoints referred directly
+	referred map[types.NamespacedName]bool
+	// endpointGroups are the endpoints of the fetched EndpointGroups
+	endpointGroups map[types.NamespacedName]*swagger.Endpoints
+}
+
+func newResolver(client *client.Client, api *wso2v1alpha2.API) *resolver {
+	return &resolver{
+		client:          client,
+		api:             api,
+		backends:        &Backends{members: map[string][]*wso2v1alpha2.TargetEndpoint{}},
+		targetEndpoints: map[types.NamespacedName]*wso2v1alpha2.TargetEndpoint{},
+		referred:        map[types.NamespacedName]bool{},
+		endpointGroups:  map[types.NamespacedName]*swagger.Endpoints{},
+	}
+}
+
+// TargetEndpointURL implements swagger.EndpointResolver
+func (r *resolver) TargetEndpointURL(ref *swagger.TargetEndpointRef) (string, error) {
+	name := namespacedName(r.api, ref)
+	targetEndpoint, err := r.getTargetEndpoint(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", &UnresolvedError{Kind: kindTargetEndpoint, Ref: ref.String(),
+				Message: "target endpoint is not found"}
+		}
+		return "", err
+	}
+	if !r.referred[name] {
+		r.referred[name] = true
+		r.backends.TargetEndpoints = append(r.backends.TargetEndpoints, targetEndpoint)
+	}
+
+	endpointURL, err := ServiceURL(targetEndpoint, ref.Port)
+	if err != nil {
+		return "", &UnresolvedError{Kind: kindTargetEndpoint, Ref: ref.String(), Message: err.Error()}
+	}
+	return endpointURL, nil
+}
+
+// EndpointGroup implements swagger.EndpointResolver
+func (r *resolver) EndpointGroup(name string) (*swagger.Endpoints, error) {
+	groupName := types.NamespacedName{Namespace: r.api.Namespace, Name: name}
+	if endpoints, ok := r.endpointGroups[groupName]; ok {
+		return endpoints, nil
+	}
+
+	group := &wso2v1alpha2.EndpointGroup{}
+	if err := k8s.Get(r.client, groupName, group); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &UnresolvedError{Kind: kindEndpointGroup, Ref: groupName.String(),
+				Message: "endpoint group is not found"}
+		}
+		return nil, err
+	}
+	endpoints, err := r.groupEndpoints(group)
+	if err != nil {
+		return nil, err
+	}
+	r.endpointGroups[groupName] = endpoints
+	r.backends.EndpointGroups = append(r.backends.EndpointGroups, group)
+	return endpoints, nil
+}
+
+// groupEndpoints returns the endpoints of the given EndpointGroup with the URLs of its members. Load balancing by
+// weights is translated to a round robin load balancing with each URL repeated by its weight.
+func (r *resolver) groupEndpoints(group *wso2v1alpha2.EndpointGroup) (*swagger.Endpoints, error) {
+	groupName := types.NamespacedName{Namespace: group.Namespace, Name: group.Name}
+	invalidErr := func(format string, a ...interface{}) error {
+		return &UnresolvedError{Kind: kindEndpointGroup, Ref: groupName.String(), Message: fmt.Sprintf(format, a...)}
+	}
+	if group.Spec.Type != wso2v1alpha2.LoadBalanceEndpointGroup &&
+		group.Spec.Type != wso2v1alpha2.FailoverEndpointGroup {
+		return nil, invalidErr("unsupported endpoint group type %q", group.Spec.Type)
+	}
+	if len(group.Spec.Members) == 0 {
+		return nil, invalidErr("endpoint group has no members")
+	}
+
+	urls := make([]string, 0, len(group.Spec.Members))
+	weights := make([]int32, 0, len(group.Spec.Members))
+	for i, member := range group.Spec.Members {
+		var memberURL string
+		switch {
+		case member.TargetEndpoint != "" && member.URL != "":
+			return nil, invalidErr("member %d defines both a target endpoint and a URL", i)
+		case member.TargetEndpoint != "":
+			name := types.NamespacedName{Namespace: group.Namespace, Name: member.TargetEndpoint}
+			targetEndpoint, err := r.getTargetEndpoint(name)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return nil, invalidErr("target endpoint %q of member %d is not found", member.TargetEndpoint, i)
+				}
+				return nil, err
+			}
+			if memberURL, err = ServiceURL(targetEndpoint, member.Port); err != nil {
+				return nil, invalidErr("target endpoint %q of member %d: %v", member.TargetEndpoint, i, err)
+			}
+			r.backends.members[groupName.String()] = append(r.backends.members[groupName.String()], targetEndpoint)
+		case member.URL != "":
+			u, err := url.Parse(member.URL)
+			if err != nil || (u.Scheme != protocolHTTP && u.Scheme != protocolHTTPS) || u.Host == "" {
+				return nil, invalidErr("invalid URL %q of member %d, expected an absolute http or https URL",
+					member.URL, i)
+			}
+			memberURL = member.URL
+		default:
+			return nil, invalidErr("member %d defines neither a target endpoint nor a URL", i)
+		}
+
+		weight := member.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		urls = append(urls, memberURL)
+		weights = append(weights, weight)
+	}
+
+	endpoints := &swagger.Endpoints{URLs: urls}
+	if len(urls) == 1 {
+		return endpoints, nil
+	}
+	switch group.Spec.Type {
+	case wso2v1alpha2.FailoverEndpointGroup:
+		endpoints.Type = swagger.EndpointTypeFailover
+	case wso2v1alpha2.LoadBalanceEndpointGroup:
+		endpoints.Type = swagger.EndpointTypeLoadBalance
+		if group.Spec.Algorithm == wso2v1alpha2.Weighted {
+			endpoints.URLs = weightedURLs(urls, weights)
+		}
+	}
+	return endpoints, nil
+}
+
+// getTargetEndpoint returns the TargetEndpoint with the given name fetching it if it is not fetched before
+func (r *resolver) getTargetEndpoint(name types.NamespacedName) (*wso2v1alpha2.TargetEndpoint, error) {
+	if targetEndpoint, ok := r.targetEndpoints[name]; ok {
+		return targetEndpoint, nil
+	}
+	targetEndpoint := &wso2v1alpha2.TargetEndpoint{}
+	if err := k8s.Get(r.client, name, targetEndpoint); err != nil {
+		return nil, err
+	}
+	r.targetEndpoints[name] = targetEndpoint
+	return targetEndpoint, nil
+}
+
+// weightedURLs returns the given URLs each repeated by its weight divided by the greatest common divisor of the
+// weights. The URLs are interleaved, so that a round robin over the returned URLs follows the weights smoothly.
+func weightedURLs(urls []string, weights []int32) []string {
+	divisor := weights[0]
+	maxWeight := weights[0]
+	for _, weight := range weights[1:] {
+		divisor = gcd(divisor, weight)
+		if weight > maxWeight {
+			maxWeight = weight
+		}
+	}
+
+	var weighted []string
+	for round := int32(0); round < maxWeight/divisor; round++ {
+		for i, endpointURL := range urls {
+			if round < weights[i]/divisor {
+				weighted = append(weighted, endpointURL)
+			}
+		}
+	}
+	return weighted
+}
+
+func gcd(a, b int32) int32 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// isGroupAvailable returns true if the given EndpointGroup has an external URL or an available TargetEndpoint
+// in the given members
+func isGroupAvailable(group *wso2v1alpha2.EndpointGroup, members []*wso2v1alpha2.TargetEndpoint) bool {
+	for _, member := range group.Spec.Members {
+		if member.URL != "" {
+			return true
+		}
+	}
+	for _, targetEndpoint := range members {
+		if isAvailable(targetEndpoint) {
+			return true
+		}
+	}
+	return false
+}
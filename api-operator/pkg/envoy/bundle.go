@@ -0,0 +1,284 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+package envoy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/digest"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/endpoints"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BundleBuilder builds the bundle of APIs served to the MGW Adapter. Bursts of update requests are coalesced to
+// a single rebuild and the API artifacts are cached by the digest of their configmaps, so that only the changed
+// APIs are packaged again.
+type BundleBuilder struct {
+	client         client.Client
+	namespace      string
+	debounceWindow time.Duration
+
+	// mux guards pending
+	mux     sync.Mutex
+	pending bool
+
+	// buildMux serializes the builds and guards the caches below
+	buildMux sync.Mutex
+	// artifacts are the packaged API artifacts keyed by the digest of the swagger configmap
+	artifacts map[string][]byte
+	// entryDigests are the digests of the entries in the last built bundle keyed by the entry name
+	entryDigests map[string]string
+	// apiDigests are the digests and the generations of the APIs the entries in the last built bundle are
+	// packaged for, keyed by the entry name
+	apiDigests map[string]apiDigest
+}
+
+// apiDigest is the digest and the generation of an API reconciled by the API controller
+type apiDigest struct {
+	digest     string
+	generation int64
+}
+
+// NewBundleBuilder returns a bundle builder for the APIs in the given namespace
+// (empty namespace means all namespaces)
+func NewBundleBuilder(client client.Client, namespace string, debounceWindow time.Duration) *BundleBuilder {
+	return &BundleBuilder{
+		client:         client,
+		namespace:      namespace,
+		debounceWindow: debounceWindow,
+		artifacts:      map[string][]byte{},
+		entryDigests:   map[string]string{},
+		apiDigests:     map[string]apiDigest{},
+	}
+}
+
+// Start builds the initial bundle once the manager is started, so that the bundle is available even if there
+// are no APIs to reconcile
+func (b *BundleBuilder) Start(stop <-chan struct{}) error {
+	b.RequestUpdate()
+	<-stop
+	return nil
+}
+
+// RequestUpdate schedules a rebuild of the bundle after the debounce window. Requests received before the
+// scheduled rebuild starts are served by the same rebuild.
+func (b *BundleBuilder) RequestUpdate() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.pending {
+		return
+	}
+	b.pending = true
+	time.AfterFunc(b.debounceWindow, b.run)
+}
+
+func (b *BundleBuilder) run() {
+	b.mux.Lock()
+	b.pending = false
+	b.mux.Unlock()
+
+	if err := b.build(context.Background()); err != nil {
+		logSendAPIs.Error(err, "Error when updating the API bundle snapshot for MGW Adapter")
+	}
+}
+
+// build rebuilds the bundle with the changed APIs and updates the snapshot served to the MGW Adapter
+func (b *BundleBuilder) build(ctx context.Context) error {
+	b.buildMux.Lock()
+	defer b.buildMux.Unlock()
+
+	apiList := &wso2v1alpha2.APIList{}
+	if err := b.client.List(ctx, apiList, client.InNamespace(b.namespace)); err != nil {
+		return err
+	}
+	items := apiList.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	entries := make([]SnapshotEntry, 0, len(items))
+	entryNames := make([]string, 0, len(items))
+	entryDigests := make(map[string]string, len(items))
+	apiDigests := make(map[string]apiDigest, len(items))
+	artifacts := make(map[string][]byte, len(items))
+	apiArtifacts := make(map[string][]byte, len(items))
+	packaged := 0
+	for _, api := range items {
+		// kubernetes resource names can not contain "_"
+		entryName := fmt.Sprintf("%s_%s.zip", api.Namespace, api.Name)
+		reconciled := apiDigest{digest: api.Status.Digest, generation: api.Generation}
+		if api.Status.Digest == "" || api.Status.ObservedGeneration != api.Generation {
+			// the API is not reconciled with its current spec, the digest in the status is not reliable
+			reconciled = apiDigest{}
+		}
+		entryDigest, artifact, built, err := b.cachedArtifact(entryName, reconciled)
+		if artifact == nil {
+			entryDigest, artifact, built, err = b.getArtifact(&api)
+		}
+		if err != nil {
+			// keep the last successfully built artifact of the API if exists
+			lastDigest, ok := b.entryDigests[entryName]
+			if !ok {
+				logSendAPIs.Error(err, "Error packaging the API, skipping it from the bundle",
+					"namespace", api.Namespace, "name", api.Name)
+				continue
+			}
+			logSendAPIs.Error(err, "Error packaging the API, using the last packaged artifact",
+				"namespace", api.Namespace, "name", api.Name)
+			entryDigest, artifact = lastDigest, b.artifacts[lastDigest]
+		}
+		if built {
+			packaged++
+		}
+
+		entries = append(entries, SnapshotEntry{Namespace: api.Namespace, Name: api.Name, Digest: entryDigest})
+		entryNames = append(entryNames, entryName)
+		entryDigests[entryName] = entryDigest
+		if err == nil && reconciled.digest != "" {
+			apiDigests[entryName] = reconciled
+		}
+		artifacts[entryDigest] = artifact
+		apiArtifacts[api.Namespace+"/"+api.Name] = artifact
+	}
+	// drop the artifacts of deleted and changed APIs
+	b.entryDigests = entryDigests
+	b.apiDigests = apiDigests
+	b.artifacts = artifacts
+
+	eTagHash := sha256.New()
+	for _, entryName := range entryNames {
+		eTagHash.Write([]byte(entryName + "=" + entryDigests[entryName] + "\n"))
+	}
+	eTag := `"` + hex.EncodeToString(eTagHash.Sum(nil)) + `"`
+	if snapshot := GetSnapshot(); snapshot != nil && snapshot.ETag == eTag {
+		logSendAPIs.V(1).Info("API bundle is not changed", "revision", snapshot.Revision)
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	for _, entryName := range entryNames {
+		// API artifacts are already compressed
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: entryName, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(artifacts[entryDigests[entryName]]); err != nil {
+			return err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		logSendAPIs.Error(err, "Error adding the zip files to a single zip file")
+		return err
+	}
+
+	snapshot := setSnapshot(eTag, entries, buf.Bytes(), apiArtifacts)
+	logSendAPIs.Info("Updated the API bundle snapshot", "revision", snapshot.Revision, "api_count", len(entryNames),
+		"packaged_api_count", packaged, "size", snapshot.Size())
+	//TODO: Send APIs set by set when there are many APIs (Eg: 1000s of APIs)
+	return nil
+}
+
+// cachedArtifact returns the digest and the artifact of the entry in the last built bundle if it is packaged for the
+// given digest of the API. The swagger, endpoints, params and secrets of an API are changed only with a change of
+// the digest in its status, so an unchanged API is not resolved again. Returns a nil artifact if there is no such
+// entry.
+func (b *BundleBuilder) cachedArtifact(entryName string, reconciled apiDigest) (string, []byte, bool, error) {
+	if reconciled.digest == "" || b.apiDigests[entryName] != reconciled {
+		return "", nil, false, nil
+	}
+	entryDigest := b.entryDigests[entryName]
+	artifact, ok := b.artifacts[entryDigest]
+	if !ok {
+		return "", nil, false, nil
+	}
+	return entryDigest, artifact, false, nil
+}
+
+// getArtifact returns the digest and the packaged artifact of the given API, and whether the artifact is packaged
+// now or taken from the cache
+func (b *BundleBuilder) getArtifact(api *wso2v1alpha2.API) (string, []byte, bool, error) {
+	inputConf := k8s.NewConfMap()
+	err := k8s.Get(&b.client, types.NamespacedName{Namespace: api.Namespace,
+		Name: api.Spec.SwaggerConfigMapName}, inputConf)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	inputConf, backends, err := endpoints.ResolveSwagger(&b.client, api, inputConf)
+	if err != nil {
+		return "", nil, false, err
+	}
+	// the API is not served to the MGW Adapter until the target endpoints have available replicas
+	if err := endpoints.CheckAvailable(backends); err != nil {
+		return "", nil, false, err
+	}
+
+	values, err := params.GetDeploymentValues(&b.client, api)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	confDigest := digest.ConfigMaps(inputConf)
+	if len(api.Spec.DeploymentEnvironments) != 0 || values != nil {
+		// artifacts of the same configmap differ by the deployment environments, params, certs and secrets
+		deploymentEnvData, err := utils.DeploymentEnvironmentsData(api.Spec.DeploymentEnvironments)
+		if err != nil {
+			return "", nil, false, err
+		}
+		confMaps := []*corev1.ConfigMap{inputConf, {
+			Data: map[string]string{utils.DeploymentEnvironmentsFile: string(deploymentEnvData)},
+		}}
+		if values != nil {
+			endpointSecurity := ""
+			if values.EndpointSecurity != nil {
+				endpointSecurity = values.EndpointSecurity.String()
+			}
+			confMaps = append(confMaps, values.ParamsCM, values.CertsCM, &corev1.ConfigMap{
+				Data: map[string]string{paramsEnvironmentKey: values.Environment,
+					endpointSecurityKey: endpointSecurity},
+			}, &corev1.ConfigMap{Data: values.SecretVersions()})
+		}
+		confDigest = digest.ConfigMaps(confMaps...)
+	}
+	if artifact, ok := b.artifacts[confDigest]; ok {
+		return confDigest, artifact, false, nil
+	}
+	artifact, err := getAPIArtifact(inputConf, api.Spec.DeploymentEnvironments, values)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return confDigest, artifact, true, nil
+}
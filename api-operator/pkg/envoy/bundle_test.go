@@ -0,0 +1,144 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+package envoy
+
+import (
+	"archive/zip"
+	"context"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func newZipConfigMap(name, content string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		BinaryData: map[string][]byte{name + ".zip": []byte(content)},
+	}
+}
+
+func newAPI(name, configMapName string) *wso2v1alpha2.API {
+	return &wso2v1alpha2.API{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       wso2v1alpha2.APISpec{SwaggerConfigMapName: configMapName},
+	}
+}
+
+func TestBundleBuilderBuild(t *testing.T) {
+
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(s)
+	cl := fake.NewFakeClientWithScheme(s,
+		newZipConfigMap("swagger1", "api1"), newAPI("api1", "swagger1"),
+		newZipConfigMap("swagger2", "api2"), newAPI("api2", "swagger2"),
+	)
+	builder := NewBundleBuilder(cl, "default", time.Second)
+
+	if err := builder.build(context.TODO()); err != nil {
+		t.Fatalf("building the bundle should not return an error: %v", err)
+	}
+	first := GetSnapshot()
+	reader, err := zip.NewReader(first.NewReader(), int64(first.Size()))
+	if err != nil {
+		t.Fatalf("bundle should be a valid zip: %v", err)
+	}
+	if len(reader.File) != 2 || reader.File[0].Name != "default_api1.zip" || reader.File[1].Name != "default_api2.zip" {
+		t.Errorf("unexpected entries in the bundle: %v", reader.File)
+	}
+
+	// rebuild without changes
+	if err := builder.build(context.TODO()); err != nil {
+		t.Fatalf("building the bundle should not return an error: %v", err)
+	}
+	if GetSnapshot() != first {
+		t.Error("snapshot should not be changed when the APIs are not changed")
+	}
+
+	// delete an API
+	if err := cl.Delete(context.TODO(), newAPI("api2", "swagger2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.build(context.TODO()); err != nil {
+		t.Fatalf("building the bundle should not return an error: %v", err)
+	}
+	second := GetSnapshot()
+	if second.Revision != first.Revision+1 {
+		t.Errorf("revision should be increased, expected: %v, actual: %v", first.Revision+1, second.Revision)
+	}
+	if len(builder.artifacts) != 1 || len(builder.entryDigests) != 1 {
+		t.Error("artifacts of the deleted API should be removed from the cache")
+	}
+	reader, err = zip.NewReader(second.NewReader(), int64(second.Size()))
+	if err != nil || len(reader.File) != 1 {
+		t.Error("bundle should contain only the remaining API")
+	}
+	if _, ok := second.GetArtifact("default", "api2"); ok {
+		t.Error("artifact of the deleted API should not be in the snapshot")
+	}
+	if _, ok := second.GetArtifact("default", "api1"); !ok || second.GetRevision("default", "api1") != first.Revision {
+		t.Error("unchanged API should be kept with the revision it last changed")
+	}
+}
+
+func TestBundleBuilderBuildReconciledAPI(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(s)
+	api := newAPI("api1", "swagger1")
+	api.Status.Digest = "digest1"
+	swaggerCM := newZipConfigMap("swagger1", "api1")
+	cl := fake.NewFakeClientWithScheme(s, swaggerCM, api)
+	builder := NewBundleBuilder(cl, "default", time.Second)
+
+	if err := builder.build(context.TODO()); err != nil {
+		t.Fatalf("building the bundle should not return an error: %v", err)
+	}
+	first := GetSnapshot()
+
+	// the configmap is not read again until the API is reconciled with the change
+	swaggerCM.BinaryData["swagger1.zip"] = []byte("api1-changed")
+	if err := cl.Update(context.TODO(), swaggerCM); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.build(context.TODO()); err != nil {
+		t.Fatalf("building the bundle should not return an error: %v", err)
+	}
+	if GetSnapshot() != first {
+		t.Error("snapshot should not be changed when the digest of the API is not changed")
+	}
+
+	if err := cl.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "api1"}, api); err != nil {
+		t.Fatal(err)
+	}
+	api.Status.Digest = "digest2"
+	if err := cl.Update(context.TODO(), api); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.build(context.TODO()); err != nil {
+		t.Fatalf("building the bundle should not return an error: %v", err)
+	}
+	if second := GetSnapshot(); second.Revision != first.Revision+1 {
+		t.Errorf("API should be packaged again when its digest is changed, revision: %v", second.Revision)
+	}
+}
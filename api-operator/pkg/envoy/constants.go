@@ -24,6 +24,7 @@ const (
 	envoyMgwSecretName           = "envoymgw-adapter-secret"
 	mgwAdapterHostConst          = "mgwAdapterHost"
 	mgwInsecureSkipVerifyConst   = "mgwInsecureSkipVerify"
+	mgwAdapterTargetPrefix       = "mgw-adapter:"
 	HeaderAuthorization          = "Authorization"
 	HeaderAccept                 = "Accept"
 	HeaderContentType            = "Content-Type"
@@ -38,6 +39,8 @@ const (
 	versionProperty              = "version"
 	apiOperatorConfigHome        = "API_OPERATOR_CONFIG_HOME"
 	apiOperatorDefaultConfigHome = "/usr/local/bin"
+	paramsEnvironmentKey         = "paramsEnvironment"
+	endpointSecurityKey          = "endpointSecurity"
 )
 
 // constants related to API-CTL project
@@ -45,13 +48,4 @@ const (
 const (
 	apiYamlFile           = "api.yaml"
 	swaggerDefinitionFile = "Definitions/swagger.yaml"
-	deploymentEnvFile     = "deployment_environments.yaml"
-	// TODO: use API-CTL code to init project
-	deploymentEnvFileData = `type: deployment_environments
-version: v4.0.0
-data:
- -
-   displayOnDevportal: true
-   deploymentEnvironment: Default
-`
 )
@@ -20,21 +20,21 @@ import (
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/apim"
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/swagger"
+	"gopkg.in/resty.v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"strconv"
 	"strings"
 )
 
 var logDelete = log.Log.WithName("mgw.envoy.delete")
-var insecureDelete = true
 
 // DeleteAPIFromMgw deletes the API from the MGW Adapter
 func DeleteAPIFromMgw(client *client.Client, api *wso2v1alpha2.API) error {
@@ -74,25 +74,18 @@ func DeleteAPIFromMgw(client *client.Client, api *wso2v1alpha2.API) error {
 
 	resourcePath := mgBasePath + mgDeleteAPIResourcePath
 	mgwEndpoint := envoyMgwConfig.Data[mgwAdapterHostConst] + resourcePath
-	var errInsecureDelete error
-	insecureDelete, errInsecureDelete = strconv.ParseBool(envoyMgwConfig.Data[mgwInsecureSkipVerifyConst])
-	if errInsecureDelete != nil {
-		return errInsecureDelete
-	}
-	if !insecureDelete {
-		errCert := getCert(client, mgwCertSecret)
-		if errCert != nil {
-			return errCert
-		}
+	httpClient, errClient := getMgwAdapterClient(client, envoyMgwConfig, mgwCertSecret)
+	if errClient != nil {
+		return errClient
 	}
 	logDelete.Info("Deleting API from Envoy MGW Adapter")
-	return deleteAPI(inputConf, authToken, mgwEndpoint)
+	return deleteAPI(httpClient, inputConf, authToken, mgwEndpoint)
 }
 
-func deleteAPI(config *corev1.ConfigMap, token string, endpoint string) error {
+func deleteAPI(httpClient *resty.Client, config *corev1.ConfigMap, token string, endpoint string) error {
 	if config.BinaryData != nil {
 		logDelete.Info("Deleting API from mgw using project zip")
-		errDeployZip := deleteAPIZip(config, token, endpoint)
+		errDeployZip := deleteAPIZip(httpClient, config, token, endpoint)
 		if errDeployZip != nil {
 			logDelete.Error(errDeployZip,
 				"Error when deleting API from mgw using Project zip")
@@ -102,7 +95,7 @@ func deleteAPI(config *corev1.ConfigMap, token string, endpoint string) error {
 
 	} else {
 		logDelete.Info("Deleting API from mgw using swagger")
-		errDeploySwagger := deleteAPISwagger(config, token, endpoint)
+		errDeploySwagger := deleteAPISwagger(httpClient, config, token, endpoint)
 		if errDeploySwagger != nil {
 			logDelete.Error(errDeploySwagger,
 				"Error when deleting API from mgw using Swagger")
@@ -112,7 +105,7 @@ func deleteAPI(config *corev1.ConfigMap, token string, endpoint string) error {
 	}
 }
 
-func deleteAPIZip(config *corev1.ConfigMap, token string, endpoint string) error {
+func deleteAPIZip(httpClient *resty.Client, config *corev1.ConfigMap, token string, endpoint string) error {
 	zipFileName, errZip := maps.OneKey(config.BinaryData)
 	if errZip != nil {
 		return errZip
@@ -136,7 +129,7 @@ func deleteAPIZip(config *corev1.ConfigMap, token string, endpoint string) error
 
 	headers := make(map[string]string)
 	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + token
-	resp, err := invokeDELETERequestWithParams(endpoint, queryParams, headers)
+	resp, err := invokeDELETERequestWithParams(httpClient, endpoint, queryParams, headers)
 
 	if err != nil {
 		return err
@@ -144,16 +137,17 @@ func deleteAPIZip(config *corev1.ConfigMap, token string, endpoint string) error
 	if resp.StatusCode() == http.StatusOK {
 		return nil
 	} else if resp.StatusCode() == http.StatusNotFound {
-		logDelete.Error(nil, "API does not exist", "api name", apiInfo.Data.Name,
-			"api version", apiInfo.Data.Version)
-	} else {
-		logDelete.Error(nil, "Error while deleting the API", "api name", apiInfo.Data.Name,
+		// nothing to delete
+		logDelete.Info("API does not exist", "api name", apiInfo.Data.Name,
 			"api version", apiInfo.Data.Version)
+		return nil
 	}
-	return nil
+	logDelete.Error(nil, "Error while deleting the API", "api name", apiInfo.Data.Name,
+		"api version", apiInfo.Data.Version, "status", resp.Status())
+	return httpclient.NewStatusError("delete API", resp)
 }
 
-func deleteAPISwagger(config *corev1.ConfigMap, token string, endpoint string) error {
+func deleteAPISwagger(httpClient *resty.Client, config *corev1.ConfigMap, token string, endpoint string) error {
 	swaggerFileName, errSwagger := maps.OneKey(config.Data)
 	if errSwagger != nil {
 		logDelete.Error(errSwagger, "Error in the swagger configmap data", "data", config.Data)
@@ -176,7 +170,7 @@ func deleteAPISwagger(config *corev1.ConfigMap, token string, endpoint string) e
 
 	headers := make(map[string]string)
 	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + token
-	resp, err := invokeDELETERequestWithParams(endpoint, queryParams, headers)
+	resp, err := invokeDELETERequestWithParams(httpClient, endpoint, queryParams, headers)
 
 	if err != nil {
 		return err
@@ -184,9 +178,11 @@ func deleteAPISwagger(config *corev1.ConfigMap, token string, endpoint string) e
 	if resp.StatusCode() == http.StatusOK {
 		return nil
 	} else if resp.StatusCode() == http.StatusNotFound {
-		logDelete.Error(nil, "API does not exist", "api name", apiName, "api version", apiVersion)
-	} else {
-		logDelete.Error(nil, "Error while deleting the API", "api name", apiName, "api version", apiVersion)
+		// nothing to delete
+		logDelete.Info("API does not exist", "api name", apiName, "api version", apiVersion)
+		return nil
 	}
-	return nil
+	logDelete.Error(nil, "Error while deleting the API", "api name", apiName, "api version", apiVersion,
+		"status", resp.Status())
+	return httpclient.NewStatusError("delete API", resp)
 }
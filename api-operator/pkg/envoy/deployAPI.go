@@ -21,7 +21,11 @@ import (
 	"fmt"
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/endpoints"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/utils"
 	"gopkg.in/resty.v1"
 	"io"
 	corev1 "k8s.io/api/core/v1"
@@ -37,10 +41,10 @@ import (
 )
 
 var logDeploy = log.Log.WithName("mgw.envoy.deploy")
-var insecureDeploy = true
 
-// Deploy API to Envoy Micro-gateway Adapter using zip file or swagger
-func DeployAPItoMgw (client *client.Client, api *wso2v1alpha2.API) error {
+// Deploy API to Envoy Micro-gateway Adapter using zip file or swagger. If override is true, the existing API
+// in the adapter is updated
+func DeployAPItoMgw (client *client.Client, api *wso2v1alpha2.API, override bool) error {
 	var tempMap map[string]string
 	envoyMgwConfig := k8s.NewConfMap()
 	errEnvoyMgw := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: envoyMgwConfName},
@@ -68,6 +72,18 @@ func DeployAPItoMgw (client *client.Client, api *wso2v1alpha2.API) error {
 		}
 	}
 
+	inputConf, _, errInput = endpoints.ResolveSwagger(client, api, inputConf)
+	if errInput != nil {
+		logDeploy.Error(errInput, "Error resolving the target endpoints of the API")
+		return errInput
+	}
+
+	deploymentValues, errValues := params.GetDeploymentValues(client, api)
+	if errValues != nil {
+		logDeploy.Error(errValues, "Error retrieving the params and certs of the API")
+		return errValues
+	}
+
 	envoyMgwSecret , errEnvoyMgwSecret := getMgAdapterSecret(client, envoyMgwSecretName)
 	if errEnvoyMgwSecret != nil {
 		return errEnvoyMgwSecret
@@ -79,32 +95,26 @@ func DeployAPItoMgw (client *client.Client, api *wso2v1alpha2.API) error {
 	resourcePath := mgBasePath + mgDeployResourcePath
 	mgwEndpoint := envoyMgwConfig.Data[mgwAdapterHostConst]+resourcePath
 
-	if api.Spec.UpdateTimeStamp != "" {
+	if override {
 		logDeploy.Info("Updating the API in Envoy MGW Adapter")
 		mgwEndpoint += "?override=" + strconv.FormatBool(true)
 	}
 
-	var errInsecureDeploy error
-	insecureDeploy, errInsecureDeploy = strconv.ParseBool(envoyMgwConfig.Data[mgwInsecureSkipVerifyConst])
-	if errInsecureDeploy != nil {
-		return errInsecureDeploy
-	}
-	if !insecureDeploy {
-		errCert := getCert(client, mgwCertSecret)
-		if errCert != nil {
-			return errCert
-		}
+	httpClient, errClient := getMgwAdapterClient(client, envoyMgwConfig, mgwCertSecret)
+	if errClient != nil {
+		return errClient
 	}
 
 	logDeploy.Info("Deploying API to Envoy MGW Adapter")
-	return deployAPI(inputConf, authToken, mgwEndpoint, tempMap)
+	return deployAPI(httpClient, inputConf, api.Spec.DeploymentEnvironments, deploymentValues, authToken, mgwEndpoint,
+		tempMap)
 
 }
 
-func deployAPI(config *corev1.ConfigMap, token string, endpoint string, extraParams map[string]string) error{
+func deployAPI(httpClient *resty.Client, config *corev1.ConfigMap, envs []wso2v1alpha2.DeploymentEnvironment, values *params.DeploymentValues, token string, endpoint string, extraParams map[string]string) error{
 	if config.BinaryData != nil {
 		logDeploy.Info("Deploying API to mgw using project zip")
-		errDeployZip := deployAPIZip(config, token, endpoint, extraParams)
+		errDeployZip := deployAPIZip(httpClient, config, envs, values, token, endpoint, extraParams)
 		if errDeployZip != nil {
 			logDeploy.Error(errDeployZip, "Error when deploying API to mgw using Project zip")
 			return errDeployZip
@@ -113,7 +123,7 @@ func deployAPI(config *corev1.ConfigMap, token string, endpoint string, extraPar
 
 	} else {
 		logDeploy.Info("Deploying API to mgw using swagger")
-		errDeploySwagger := deployAPISwagger(config, token, endpoint, extraParams)
+		errDeploySwagger := deployAPISwagger(httpClient, config, envs, values, token, endpoint, extraParams)
 		if errDeploySwagger != nil {
 			logDeploy.Error(errDeploySwagger, "Error when deploying API to mgw using Swagger")
 			return errDeploySwagger
@@ -122,12 +132,28 @@ func deployAPI(config *corev1.ConfigMap, token string, endpoint string, extraPar
 	}
 }
 
-func deployAPIZip(config *corev1.ConfigMap, token string, endpoint string, extraParams map[string]string) error {
-	fileName, err := getZipData(config)
+func deployAPIZip(httpClient *resty.Client, config *corev1.ConfigMap, envs []wso2v1alpha2.DeploymentEnvironment, values *params.DeploymentValues, token string, endpoint string, extraParams map[string]string) error {
+	zipFileName, err := getZipData(config)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(zipFileName)
+	projectZipFile, cleanupFunc, err := utils.OverrideDeploymentEnvironments(zipFileName, envs)
+	if err != nil {
+		return err
+	}
+	//cleanup the temporary artifacts once consuming the zip file
+	if cleanupFunc != nil {
+		defer cleanupFunc()
+	}
+	fileName, valuesCleanupFunc, err := applyDeploymentValues(projectZipFile, values)
 	if err != nil {
 		return err
 	}
-	resp, errResp := executeNewFileUploadRequest(endpoint, extraParams, "file",
+	if valuesCleanupFunc != nil {
+		defer valuesCleanupFunc()
+	}
+	resp, errResp := executeNewFileUploadRequest(httpClient, endpoint, extraParams, "file",
 		fileName, token)
 	if errResp != nil {
 		return errResp
@@ -138,37 +164,45 @@ func deployAPIZip(config *corev1.ConfigMap, token string, endpoint string, extra
 		return nil
 	} else {
 		// We have an HTTP error
-		return fmt.Errorf("Unable to upload API. Status:" + resp.Status())
+		return httpclient.NewStatusError("upload API", resp)
 	}
 }
 
-func deployAPISwagger(config *corev1.ConfigMap, token string, endpoint string, extraParams map[string]string) error{
-	swaggerZipFile, cleanupFunc, errSwaggerData := getSwaggerData(config)
+func deployAPISwagger(httpClient *resty.Client, config *corev1.ConfigMap, envs []wso2v1alpha2.DeploymentEnvironment, values *params.DeploymentValues, token string, endpoint string, extraParams map[string]string) error{
+	swaggerZipFile, cleanupFunc, errSwaggerData := getSwaggerData(config, envs)
 	if errSwaggerData != nil {
 		return errSwaggerData
 	}
 
-	resp, errResp := executeNewFileUploadRequest(endpoint, extraParams, "file",
-		swaggerZipFile, token)
-
-	if errResp != nil {
-		return errResp
-	}
 	//cleanup the temporary artifacts once consuming the zip file
 	if cleanupFunc != nil {
 		defer cleanupFunc()
 	}
+	fileName, valuesCleanupFunc, err := applyDeploymentValues(swaggerZipFile, values)
+	if err != nil {
+		return err
+	}
+	if valuesCleanupFunc != nil {
+		defer valuesCleanupFunc()
+	}
+
+	resp, errResp := executeNewFileUploadRequest(httpClient, endpoint, extraParams, "file",
+		fileName, token)
+
+	if errResp != nil {
+		return errResp
+	}
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
 		fmt.Println("Successfully deployed API.")
 		return nil
 	} else {
 		// We have an HTTP error
-		return fmt.Errorf("Unable to upload API. Status:" + resp.Status())
+		return httpclient.NewStatusError("upload API", resp)
 	}
 }
 
-func executeNewFileUploadRequest(uri string, params map[string]string, paramName, path,
+func executeNewFileUploadRequest(httpClient *resty.Client, uri string, params map[string]string, paramName, path,
 	accessToken string) (*resty.Response, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -198,6 +232,6 @@ func executeNewFileUploadRequest(uri string, params map[string]string, paramName
 	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + accessToken
 	headers[HeaderAccept] = "*/*"
 	headers[HeaderConnection] = HeaderValueKeepAlive
-	resp, err := invokePOSTRequestWithBytes(uri, headers, body.Bytes())
+	resp, err := invokePOSTRequestWithBytes(httpClient, uri, headers, body.Bytes())
 	return resp, err
 }
@@ -0,0 +1,77 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package envoy
+
+import (
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigObjectNames returns the names of the configmap and secrets in the system namespace that configure the
+// connection to the MGW Adapter
+func ConfigObjectNames(client *client.Client) []string {
+	names := []string{envoyMgwConfName, envoyMgwSecretName}
+	envoyMgwSecret, err := getMgAdapterSecret(client, envoyMgwSecretName)
+	if err == nil && len(envoyMgwSecret.Data[mgwCertSecretName]) != 0 {
+		names = append(names, string(envoyMgwSecret.Data[mgwCertSecretName]))
+	}
+	return names
+}
+
+// InvalidateCaches discards the cached HTTP clients of the MGW Adapter, so that changes to the adapter configs and
+// credentials take effect
+func InvalidateCaches() {
+	httpclient.InvalidateWithPrefix(mgwAdapterTargetPrefix)
+}
+
+// ReloadConfigs discards the cached configs of the MGW Adapter with InvalidateCaches and validates the connectivity
+// and the credentials with a request to the adapter
+func ReloadConfigs(client *client.Client) error {
+	InvalidateCaches()
+
+	envoyMgwConfig := k8s.NewConfMap()
+	err := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: envoyMgwConfName},
+		envoyMgwConfig)
+	if err != nil {
+		return err
+	}
+	envoyMgwSecret, err := getMgAdapterSecret(client, envoyMgwSecretName)
+	if err != nil {
+		return err
+	}
+	httpClient, err := getMgwAdapterClient(client, envoyMgwConfig, string(envoyMgwSecret.Data[mgwCertSecretName]))
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + getAuthToken(envoyMgwSecret)
+	url := envoyMgwConfig.Data[mgwAdapterHostConst] + mgBasePath + mgDeployResourcePath
+	resp, err := invokeGETRequest(httpClient, url, headers)
+	if err != nil {
+		return err
+	}
+	// any response other than an authorization failure shows the adapter is reachable with the credentials
+	if resp.StatusCode() == http.StatusUnauthorized || resp.StatusCode() == http.StatusForbidden {
+		return httpclient.NewStatusError("authenticate with the MGW Adapter", resp)
+	}
+	return nil
+}
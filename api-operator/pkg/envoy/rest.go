@@ -17,53 +17,23 @@
 package envoy
 
 import (
-	"crypto/tls"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"gopkg.in/resty.v1"
-	"os"
-	"time"
 )
 
-func invokePOSTRequestWithBytes(url string, headers map[string]string, body []byte) (*resty.Response, error) {
-	if insecureDeploy {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(&tls.Config{RootCAs: certPool, InsecureSkipVerify: false})
-	}
-
-	if os.Getenv("HTTP_PROXY") != "" {
-		resty.SetProxy(os.Getenv("HTTP_PROXY"))
-	} else if os.Getenv("HTTPS_PROXY") != "" {
-		resty.SetProxy(os.Getenv("HTTPS_PROXY"))
-	} else if os.Getenv("http_proxy") != "" {
-		resty.SetProxy(os.Getenv("http_proxy"))
-	} else if os.Getenv("https_proxy") != "" {
-		resty.SetProxy(os.Getenv("https_proxy"))
-	}
-	resty.SetTimeout(time.Duration(DefaultHttpRequestTimeout) * time.Millisecond)
-	resp, err := resty.R().SetHeaders(headers).SetBody(body).Post(url)
-	return resp, err
-}
-
-func invokeDELETERequestWithParams(url string, params map[string]string, headers map[string]string) (
+func invokePOSTRequestWithBytes(httpClient *resty.Client, url string, headers map[string]string, body []byte) (
 	*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).SetBody(body).Post(url)
+	return resp, httpclient.NewRequestError("send POST request to "+url, err)
+}
 
-	if insecureDelete {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(&tls.Config{RootCAs: certPool, InsecureSkipVerify: false})
-	}
-
-	if os.Getenv("HTTP_PROXY") != "" {
-		resty.SetProxy(os.Getenv("HTTP_PROXY"))
-	} else if os.Getenv("HTTPS_PROXY") != "" {
-		resty.SetProxy(os.Getenv("HTTPS_PROXY"))
-	} else if os.Getenv("http_proxy") != "" {
-		resty.SetProxy(os.Getenv("http_proxy"))
-	} else if os.Getenv("https_proxy") != "" {
-		resty.SetProxy(os.Getenv("https_proxy"))
-	}
-	resty.SetTimeout(time.Duration(DefaultHttpRequestTimeout) * time.Millisecond)
-	resp, err := resty.R().SetHeaders(headers).SetQueryParams(params).Delete(url)
-	return resp, err
+func invokeDELETERequestWithParams(httpClient *resty.Client, url string, params map[string]string,
+	headers map[string]string) (*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).SetQueryParams(params).Delete(url)
+	return resp, httpclient.NewRequestError("send DELETE request to "+url, err)
 }
 
+func invokeGETRequest(httpClient *resty.Client, url string, headers map[string]string) (*resty.Response, error) {
+	resp, err := httpClient.R().SetHeaders(headers).Get(url)
+	return resp, httpclient.NewRequestError("send GET request to "+url, err)
+}
@@ -18,64 +18,57 @@ package envoy
 
 import (
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
-	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
-	"k8s.io/apimachinery/pkg/types"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/utils"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
 	"os"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var logSendAPIs = log.Log.WithName("mgw.envoy.sendAPIs")
 
-// CreateFileToSend creates the zipped file with all the APIs
-func CreateFileToSend(apiList *wso2v1alpha2.APIList, client *client.Client) error {
+// getAPIArtifact returns the API project zip of the given swagger or project zip configmap, to be deployed to the
+// given gateway environments with the given params and certs
+func getAPIArtifact(config *corev1.ConfigMap, envs []wso2v1alpha2.DeploymentEnvironment,
+	values *params.DeploymentValues) ([]byte, error) {
+	artifact, err := getProjectArtifact(config, envs)
+	if err != nil || values == nil {
+		return artifact, err
+	}
+	return values.Archive(artifact)
+}
+
+// getProjectArtifact returns the API project zip of the given swagger or project zip configmap, to be deployed to the
+// given gateway environments
+func getProjectArtifact(config *corev1.ConfigMap, envs []wso2v1alpha2.DeploymentEnvironment) ([]byte, error) {
 	var fileName string
 	var cleanupFunc func()
-	var files []string
-	for _, api := range apiList.Items {
-		inputConf := k8s.NewConfMap()
-		err := k8s.Get(client, types.NamespacedName{Namespace: api.Namespace,
-			Name: api.Spec.SwaggerConfigMapName}, inputConf)
-		if err != nil {
-			return err
-		}
-		if inputConf.BinaryData != nil {
-			fileName, err = getZipData(inputConf)
+	var err error
+	if config.BinaryData != nil {
+		if len(envs) == 0 {
+			zipFileName, err := maps.OneKey(config.BinaryData)
 			if err != nil {
-				return err
-			}
-		} else {
-			fileName, cleanupFunc, err = getSwaggerData(inputConf)
-			if err != nil {
-				return err
+				return nil, err
 			}
+			return config.BinaryData[zipFileName], nil
+		}
+		zipFileName, err := getZipData(config)
+		if err != nil {
+			return nil, err
 		}
-		files = append(files, fileName)
+		defer os.Remove(zipFileName)
+		fileName, cleanupFunc, err = utils.OverrideDeploymentEnvironments(zipFileName, envs)
+	} else {
+		fileName, cleanupFunc, err = getSwaggerData(config, envs)
 	}
-
-	tmp := os.TempDir() + "/done.zip"
-	err := ZipFiles(tmp, files)
 	if err != nil {
-		logSendAPIs.Error(err, "Error adding the zip files to a single zip file")
-		return err
+		return nil, err
 	}
-	//TODO: Send APIs set by set when there are many APIs (Eg: 1000s of APIs)
-	//// Split the files array into chunks of 100 in size (100 APIs in one chunk)
-	//limit := 100
-	//for i := 0; i < len(files); i += limit {
-	//	batch := files[i:min(i+limit, len(files))]
-	//	tmp := fmt.Sprintf(os.TempDir()+ "/done-%d.zip", i)
-	//	// Add files to zip
-	//	err := ZipFiles(tmp, batch)
-	//	if err != nil {
-	//		logSendAPIs.Error(err, "Error adding the zip files to a single zip file")
-	//		return err
-	//	}
-	//}
-
 	//cleanup the temporary artifacts once consuming the zip file
 	if cleanupFunc != nil {
 		defer cleanupFunc()
 	}
-	return nil
+	return ioutil.ReadFile(fileName)
 }
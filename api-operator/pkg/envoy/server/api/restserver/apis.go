@@ -0,0 +1,95 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-openapi/swag"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/models"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listAPIInfo returns a page of the APIs matching the given namespace and label selector sorted by the namespace
+// and name, with the details of the APIs in the given snapshot
+func listAPIInfo(ctx context.Context, namespace string, selector labels.Selector, offset, limit int64,
+	snapshot *envoy.Snapshot) (*models.APIInfoList, error) {
+	apiList := &wso2v1alpha2.APIList{}
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := k8sClient.List(ctx, apiList, opts...); err != nil {
+		return nil, err
+	}
+
+	items := apiList.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	total := int64(len(items))
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	list := make([]*models.APIInfo, 0, end-start)
+	for i := start; i < end; i++ {
+		list = append(list, toAPIInfo(&items[i], snapshot))
+	}
+	info := &models.APIInfoList{
+		Count:  swag.Int64(int64(len(list))),
+		Total:  swag.Int64(total),
+		Offset: offset,
+		List:   list,
+	}
+	if snapshot != nil {
+		info.Revision = int64(snapshot.Revision)
+	}
+	return info, nil
+}
+
+// toAPIInfo returns the details of the given API with the digest and revision of it in the given snapshot
+func toAPIInfo(api *wso2v1alpha2.API, snapshot *envoy.Snapshot) *models.APIInfo {
+	info := &models.APIInfo{
+		Namespace: swag.String(api.Namespace),
+		Name:      swag.String(api.Name),
+		APIName:   api.Status.Name,
+		Version:   api.Status.Version,
+		BasePath:  api.Status.BasePath,
+		Mode:      string(api.Spec.Mode),
+	}
+	if snapshot == nil {
+		return info
+	}
+	if entry, ok := snapshot.GetEntry(api.Namespace, api.Name); ok {
+		info.Digest = entry.Digest
+		info.Revision = int64(snapshot.GetRevision(api.Namespace, api.Name))
+	}
+	return info
+}
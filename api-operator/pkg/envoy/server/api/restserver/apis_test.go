@@ -0,0 +1,66 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestListAPIInfo(t *testing.T) {
+
+	s := runtime.NewScheme()
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(s)
+	newAPI := func(namespace, name, team string) *wso2v1alpha2.API {
+		return &wso2v1alpha2.API{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"team": team}},
+			Status:     wso2v1alpha2.APIStatus{Name: name + "-api", Version: "v1"},
+		}
+	}
+	k8sClient = fake.NewFakeClientWithScheme(s, newAPI("ns2", "api1", "a"), newAPI("ns1", "api2", "b"),
+		newAPI("ns1", "api1", "a"))
+
+	infoList, err := listAPIInfo(context.TODO(), "", labels.Everything(), 0, 2, nil)
+	if err != nil {
+		t.Fatalf("listing the APIs should not return an error: %v", err)
+	}
+	if *infoList.Total != 3 || *infoList.Count != 2 || *infoList.List[0].Namespace != "ns1" ||
+		*infoList.List[0].Name != "api1" || *infoList.List[1].Name != "api2" || infoList.List[0].APIName != "api1-api" {
+		t.Errorf("unexpected first page of the APIs: %v", infoList)
+	}
+
+	infoList, _ = listAPIInfo(context.TODO(), "", labels.Everything(), 2, 2, nil)
+	if *infoList.Total != 3 || *infoList.Count != 1 || *infoList.List[0].Namespace != "ns2" {
+		t.Errorf("unexpected last page of the APIs: %v", infoList)
+	}
+
+	infoList, _ = listAPIInfo(context.TODO(), "", labels.Everything(), 10, 2, nil)
+	if *infoList.Total != 3 || *infoList.Count != 0 {
+		t.Errorf("page after the last API should be empty: %v", infoList)
+	}
+
+	selector, _ := labels.Parse("team=a")
+	infoList, _ = listAPIInfo(context.TODO(), "ns1", selector, 0, 10, nil)
+	if *infoList.Total != 1 || *infoList.List[0].Namespace != "ns1" || *infoList.List[0].Name != "api1" {
+		t.Errorf("APIs should be filtered by the namespace and labels: %v", infoList)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/security"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/models"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"sync"
+	"time"
+)
+
+// authConfig is the authentication configuration of the rest server
+type authConfig struct {
+	mode string
+	// basicAuthSecret is the name of the secret with the username and password for the basic auth mode
+	basicAuthSecret string
+	// allowedServiceAccounts are the service accounts allowed in the bearer mode in the form "namespace/name"
+	allowedServiceAccounts []string
+	// clientCASecret is the name of the secret with the CA certs to verify client certs in the mTLS mode
+	clientCASecret string
+}
+
+// tokenReview is a cached result of a successful token review
+type tokenReview struct {
+	username string
+	expiry   time.Time
+}
+
+var (
+	tokenReviewMux   sync.Mutex
+	tokenReviewCache = map[string]tokenReview{}
+)
+
+// getRestServerConfMap returns the rest server configmap in the system namespace or nil if it is not found
+func getRestServerConfMap(ctx context.Context, reader client.Reader) (*corev1.ConfigMap, error) {
+	confMap := &corev1.ConfigMap{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: config.SystemNamespace, Name: restServerConfName}, confMap)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return confMap, nil
+}
+
+// getAuthConfig returns the authentication configuration from the rest server configmap in the system namespace
+// or the default configuration if the configmap is not found
+func getAuthConfig(ctx context.Context) (*authConfig, error) {
+	authConf := &authConfig{mode: authModeBasic, basicAuthSecret: defaultBasicAuthSecretName}
+
+	confMap, err := getRestServerConfMap(ctx, k8sClient)
+	if err != nil || confMap == nil {
+		return authConf, err
+	}
+
+	if mode := strings.TrimSpace(confMap.Data[authModeConst]); mode != "" {
+		authConf.mode = strings.ToLower(mode)
+	}
+	switch authConf.mode {
+	case authModeBasic, authModeBearer, authModeMTLS:
+	default:
+		return nil, fmt.Errorf("invalid auth mode %q in configmap %s/%s, should be one of %q, %q or %q",
+			authConf.mode, config.SystemNamespace, restServerConfName, authModeBasic, authModeBearer, authModeMTLS)
+	}
+	if secret := strings.TrimSpace(confMap.Data[basicAuthSecretConst]); secret != "" {
+		authConf.basicAuthSecret = secret
+	}
+	for _, sa := range strings.Split(confMap.Data[allowedServiceAccountsConst], ",") {
+		if sa = strings.TrimSpace(sa); sa != "" {
+			authConf.allowedServiceAccounts = append(authConf.allowedServiceAccounts, sa)
+		}
+	}
+	authConf.clientCASecret = strings.TrimSpace(confMap.Data[clientCASecretConst])
+	return authConf, nil
+}
+
+// isServiceAccountAllowed returns true if the given user is an allowed service account. If no service accounts are
+// configured, service accounts in the system namespace are allowed.
+func (c *authConfig) isServiceAccountAllowed(username string) bool {
+	if !strings.HasPrefix(username, serviceAccountUserPrefix) {
+		return false
+	}
+	// username is in the form "system:serviceaccount:<namespace>:<name>"
+	saParts := strings.SplitN(strings.TrimPrefix(username, serviceAccountUserPrefix), ":", 2)
+	if len(saParts) != 2 {
+		return false
+	}
+	if len(c.allowedServiceAccounts) == 0 {
+		return saParts[0] == config.SystemNamespace
+	}
+	for _, sa := range c.allowedServiceAccounts {
+		if sa == saParts[0]+"/"+saParts[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// newAuthenticator returns an authenticator that authenticates requests with the auth mode configured in the
+// rest server configmap
+func newAuthenticator(basicAuthenticate security.UserPassAuthentication) runtime.Authenticator {
+	basicAuthenticator := security.BasicAuth(basicAuthenticate)
+	return security.HttpAuthenticator(func(r *http.Request) (bool, interface{}, error) {
+		authConf, err := getAuthConfig(r.Context())
+		if err != nil {
+			logRestServer.Error(err, "Error reading the auth configuration of the rest server")
+			return true, nil, errors.New(http.StatusInternalServerError, "Error reading the auth configuration")
+		}
+
+		switch authConf.mode {
+		case authModeBearer:
+			return authenticateBearer(r, authConf)
+		case authModeMTLS:
+			return authenticateClientCert(r)
+		default:
+			return basicAuthenticator.Authenticate(r)
+		}
+	})
+}
+
+// basicAuth validates the given credentials with the credentials in the basic auth secret
+func basicAuth(user string, pass string) (*models.Principal, error) {
+	ctx := context.Background()
+	authConf, err := getAuthConfig(ctx)
+	if err != nil {
+		logRestServer.Error(err, "Error reading the auth configuration of the rest server")
+		return nil, errors.New(http.StatusInternalServerError, "Error reading the auth configuration")
+	}
+
+	secret := &corev1.Secret{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: config.SystemNamespace, Name: authConf.basicAuthSecret},
+		secret)
+	if k8sErrors.IsNotFound(err) {
+		// no default credentials are shipped, basic auth is refused until the secret is created
+		logRestServer.Info("Credentials of the rest server are not created, refusing basic auth",
+			"secret", authConf.basicAuthSecret, "namespace", config.SystemNamespace)
+		return nil, errors.New(http.StatusUnauthorized, "Basic auth credentials are not configured")
+	}
+	if err != nil {
+		logRestServer.Error(err, "Error reading the credentials of the rest server",
+			"secret", authConf.basicAuthSecret, "namespace", config.SystemNamespace)
+		return nil, errors.New(http.StatusInternalServerError, "Error reading the credentials")
+	}
+
+	username, password := secret.Data[usernameConst], secret.Data[passwordConst]
+	if len(username) == 0 || len(password) == 0 {
+		logRestServer.Info("Username or password is not defined in the credentials of the rest server",
+			"secret", authConf.basicAuthSecret, "namespace", config.SystemNamespace)
+		return nil, errors.New(http.StatusUnauthorized, "Credentials are invalid")
+	}
+	// evaluate both to avoid leaking which one is invalid with the response time
+	userMatch := subtle.ConstantTimeCompare([]byte(user), username)
+	passMatch := subtle.ConstantTimeCompare([]byte(pass), password)
+	if userMatch&passMatch != 1 {
+		return nil, errors.New(http.StatusUnauthorized, "Credentials are invalid")
+	}
+
+	return &models.Principal{Username: user}, nil
+}
+
+// authenticateBearer validates the kubernetes service account token in the authorization header
+func authenticateBearer(r *http.Request, authConf *authConfig) (bool, interface{}, error) {
+	authHeader := r.Header.Get(headerAuthorization)
+	if !strings.HasPrefix(authHeader, headerValueAuthBearerPrefix+" ") {
+		return false, nil, nil
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(authHeader, headerValueAuthBearerPrefix+" "))
+	if token == "" {
+		return false, nil, nil
+	}
+
+	username, err := reviewToken(r.Context(), token)
+	if err != nil {
+		return true, nil, err
+	}
+	if !authConf.isServiceAccountAllowed(username) {
+		logRestServer.Info("Service account is not allowed to access the rest server", "user", username)
+		return true, nil, errors.New(http.StatusUnauthorized, "Token is not allowed")
+	}
+	return true, &models.Principal{Username: username}, nil
+}
+
+// reviewToken returns the username of the given token validated with a TokenReview. Successful reviews are cached
+// for a short duration to avoid a review per request.
+func reviewToken(ctx context.Context, token string) (string, error) {
+	tokenHash := sha256.Sum256([]byte(token))
+	cacheKey := hex.EncodeToString(tokenHash[:])
+
+	tokenReviewMux.Lock()
+	cached, ok := tokenReviewCache[cacheKey]
+	tokenReviewMux.Unlock()
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.username, nil
+	}
+
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := k8sClient.Create(ctx, review); err != nil {
+		logRestServer.Error(err, "Error reviewing the bearer token")
+		return "", errors.New(http.StatusInternalServerError, "Error reviewing the token")
+	}
+	if !review.Status.Authenticated {
+		logRestServer.Info("Bearer token is not authenticated", "error", review.Status.Error)
+		return "", errors.New(http.StatusUnauthorized, "Token is invalid")
+	}
+
+	now := time.Now()
+	tokenReviewMux.Lock()
+	for key, review := range tokenReviewCache {
+		if now.After(review.expiry) {
+			delete(tokenReviewCache, key)
+		}
+	}
+	tokenReviewCache[cacheKey] = tokenReview{username: review.Status.User.Username, expiry: now.Add(tokenReviewTTL)}
+	tokenReviewMux.Unlock()
+	return review.Status.User.Username, nil
+}
+
+// authenticateClientCert validates the client cert verified in the TLS handshake
+func authenticateClientCert(r *http.Request) (bool, interface{}, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return false, nil, nil
+	}
+	return true, &models.Principal{Username: r.TLS.VerifiedChains[0][0].Subject.CommonName}, nil
+}
+
+// getClientTLSConfig returns the TLS config that requires client certs in the mTLS auth mode, or nil to use the
+// given TLS config in other modes
+func getClientTLSConfig(tlsConfig *tls.Config) (*tls.Config, error) {
+	ctx := context.Background()
+	authConf, err := getAuthConfig(ctx)
+	if err != nil {
+		logRestServer.Error(err, "Error reading the auth configuration of the rest server")
+		return nil, err
+	}
+	if authConf.mode != authModeMTLS {
+		return nil, nil
+	}
+	if authConf.clientCASecret == "" {
+		return nil, fmt.Errorf("client CA secret is not defined for the auth mode %q", authModeMTLS)
+	}
+
+	secret := &corev1.Secret{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: config.SystemNamespace, Name: authConf.clientCASecret},
+		secret)
+	if err != nil {
+		logRestServer.Error(err, "Error reading the client CA secret of the rest server",
+			"secret", authConf.clientCASecret, "namespace", config.SystemNamespace)
+		return nil, err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(secret.Data[caCertConst]) {
+		return nil, fmt.Errorf("no valid CA certs in the key %q of the secret %s/%s", caCertConst,
+			config.SystemNamespace, authConf.clientCASecret)
+	}
+
+	clientConfig := tlsConfig.Clone()
+	clientConfig.GetConfigForClient = nil
+	clientConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	clientConfig.ClientCAs = clientCAs
+	return clientConfig, nil
+}
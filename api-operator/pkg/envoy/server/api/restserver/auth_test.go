@@ -0,0 +1,98 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestBasicAuth(t *testing.T) {
+
+	k8sClient = fake.NewFakeClientWithScheme(scheme.Scheme)
+	if _, err := basicAuth("admin", "admin"); err == nil {
+		t.Error("basic auth should be refused until the credentials secret is created")
+	}
+
+	k8sClient = fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: defaultBasicAuthSecretName},
+		Data:       map[string][]byte{usernameConst: []byte("adapter"), passwordConst: []byte("secret")},
+	})
+
+	if principal, err := basicAuth("adapter", "secret"); err != nil || principal.Username != "adapter" {
+		t.Error("valid credentials should be authenticated")
+	}
+	if _, err := basicAuth("adapter", "admin"); err == nil {
+		t.Error("invalid password should not be authenticated")
+	}
+	if _, err := basicAuth("admin", "admin"); err == nil {
+		t.Error("default credentials should not be authenticated")
+	}
+}
+
+func TestGetAuthConfig(t *testing.T) {
+
+	k8sClient = fake.NewFakeClientWithScheme(scheme.Scheme)
+	authConf, err := getAuthConfig(context.TODO())
+	if err != nil || authConf.mode != authModeBasic || authConf.basicAuthSecret != defaultBasicAuthSecretName {
+		t.Error("default auth config should be returned when the configmap is not found")
+	}
+
+	k8sClient = fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: restServerConfName},
+		Data:       map[string]string{authModeConst: "Bearer", allowedServiceAccountsConst: "default/adapter, ns/sa"},
+	})
+	authConf, err = getAuthConfig(context.TODO())
+	if err != nil || authConf.mode != authModeBearer || len(authConf.allowedServiceAccounts) != 2 {
+		t.Errorf("unexpected auth config: %v, error: %v", authConf, err)
+	}
+
+	k8sClient = fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: restServerConfName},
+		Data:       map[string]string{authModeConst: "none"},
+	})
+	if _, err = getAuthConfig(context.TODO()); err == nil {
+		t.Error("invalid auth mode should return an error")
+	}
+}
+
+func TestIsServiceAccountAllowed(t *testing.T) {
+
+	authConf := &authConfig{}
+	if !authConf.isServiceAccountAllowed(serviceAccountUserPrefix + config.SystemNamespace + ":adapter") {
+		t.Error("service accounts in the system namespace should be allowed by default")
+	}
+	if authConf.isServiceAccountAllowed(serviceAccountUserPrefix + "default:adapter") {
+		t.Error("service accounts in other namespaces should not be allowed by default")
+	}
+
+	authConf = &authConfig{allowedServiceAccounts: []string{"default/adapter"}}
+	if !authConf.isServiceAccountAllowed(serviceAccountUserPrefix + "default:adapter") {
+		t.Error("configured service account should be allowed")
+	}
+	if authConf.isServiceAccountAllowed(serviceAccountUserPrefix + "default:other") {
+		t.Error("service account not configured should not be allowed")
+	}
+	if authConf.isServiceAccountAllowed("admin") {
+		t.Error("users other than service accounts should not be allowed")
+	}
+}
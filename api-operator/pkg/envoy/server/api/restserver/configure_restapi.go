@@ -17,25 +17,42 @@
 package restserver
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"fmt"
 	"github.com/go-openapi/errors"
-	"github.com/go-openapi/loads"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
-	"github.com/jessevdk/go-flags"
+	"github.com/go-openapi/swag"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/models"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/restserver/operations"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/restserver/operations/a_p_is_all"
-	"io"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/restserver/operations/a_p_is_individual"
 	"io/ioutil"
-	"log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"net/http"
-	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strings"
+	"time"
 )
 
 var logRestServer = logf.Log.WithName("server.operator")
 
+var (
+	// k8sClient is the client used to read the configurations of the rest server
+	k8sClient client.Client
+	// tlsConf is the TLS configuration of the rest server read at the startup
+	tlsConf *tlsServerConfig
+	// certLoader loads the TLS certificate of the rest server
+	certLoader *certificateLoader
+)
+
 //go:generate swagger generate server --target ../../api --name Restapi --spec ../../resources/apiSwagger.yaml --server-package restserver --principal models.Principal
 
 func configureFlags(api *operations.RestapiAPI) {
@@ -48,9 +65,9 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 
 	// Set your custom logger if needed. Default one is log.Printf
 	// Expected interface func(string, ...interface{})
-	//
-	// Example:
-	// api.Logger = log.Printf
+	api.Logger = func(format string, args ...interface{}) {
+		logRestServer.Info(fmt.Sprintf(format, args...))
+	}
 
 	api.UseSwaggerUI()
 	// To continue using redoc as your UI, uncomment the following line
@@ -58,47 +75,101 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 
 	api.JSONConsumer = runtime.JSONConsumer()
 
-	api.ApplicationZipProducer = runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
-		dataZip, err := ioutil.ReadFile(os.TempDir() + "/done.zip")
-		if err != nil {
-			logRestServer.Error(err, "Error when reading zipped file")
-			return err
-		}
-		if dataZip != nil {
-			_, err = w.Write(dataZip)
-			if err != nil {
-				logRestServer.Error(err, "Error when writing zipped file to response")
-				return err
-			}
-		}
-		return nil
-	})
+	api.ApplicationZipProducer = runtime.ByteStreamProducer()
 
 	// Applies when the Authorization header is set with the Basic scheme
-	api.BasicAuthAuth = func(user string, pass string) (*models.Principal, error) {
-		if user != "admin" || pass != "admin" {
-			return nil, errors.New(401, "Credentials are invalid")
-		}
-
-		p := models.Principal{
-			Token:    "xxxx",
-			Tenant:   "xxxx",
-			Username: user,
-		}
-		return &p, nil
-	}
+	api.BasicAuthAuth = basicAuth
+	// Authenticates with basic auth, service account tokens or client certs as configured
+	api.BasicAuthenticator = newAuthenticator
 
 	// Set your custom authorizer if needed. Default one is security.Authorized()
 	// Expected interface runtime.Authorizer
 	//
 	// Example:
 	// api.APIAuthorizer = security.Authorized()
-	if api.ApIsAllGetApisHandler == nil {
-		api.ApIsAllGetApisHandler = a_p_is_all.GetApisHandlerFunc(func(params a_p_is_all.GetApisParams,
-			principal *models.Principal) middleware.Responder {
-			return middleware.NotImplemented("operation a_p_is_all.GetApis has not yet been implemented")
+	api.ApIsAllGetApisHandler = a_p_is_all.GetApisHandlerFunc(func(params a_p_is_all.GetApisParams,
+		principal *models.Principal) middleware.Responder {
+		snapshot := envoy.GetSnapshot()
+		if *params.Format == formatJSON {
+			return getAPIInfoList(params, snapshot)
+		}
+		if snapshot == nil {
+			return a_p_is_all.NewGetApisNotFound().WithPayload(&models.Error{
+				Code:    swag.Int64(http.StatusNotFound),
+				Message: swag.String("API bundle snapshot is not available yet"),
+			})
+		}
+
+		revision := int64(snapshot.Revision)
+		if params.IfNoneMatch != nil && eTagMatches(*params.IfNoneMatch, snapshot.ETag) {
+			return a_p_is_all.NewGetApisNotModified().WithETag(snapshot.ETag).WithXOperatorRevision(revision)
+		}
+
+		logRestServer.V(1).Info("Sending API bundle snapshot", "revision", snapshot.Revision, "size", snapshot.Size())
+		return a_p_is_all.NewGetApisOK().WithETag(snapshot.ETag).WithXOperatorRevision(revision).
+			WithPayload(ioutil.NopCloser(snapshot.NewReader()))
+	})
+
+	api.ApIsAllGetApisChangesHandler = a_p_is_all.GetApisChangesHandlerFunc(func(params a_p_is_all.GetApisChangesParams,
+		principal *models.Principal) middleware.Responder {
+		revision := uint64(*params.Revision)
+		ctx, cancelStop := withLongPollStop(params.HTTPRequest.Context())
+		defer cancelStop()
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(*params.Timeout)*time.Second)
+		defer cancel()
+
+		snapshot := envoy.WaitForSnapshot(ctx, revision)
+		if snapshot == nil {
+			// no snapshot is built yet, the client should retry with the same revision
+			return a_p_is_all.NewGetApisChangesOK().WithPayload(&models.APIChanges{Revision: params.Revision})
+		}
+
+		changes := envoy.GetChanges(snapshot, revision)
+		return a_p_is_all.NewGetApisChangesOK().WithPayload(&models.APIChanges{
+			Revision:   swag.Int64(int64(changes.Revision)),
+			FullResync: changes.FullResync,
+			Added:      toAPIChangeItems(changes.Added),
+			Updated:    toAPIChangeItems(changes.Updated),
+			Removed:    toAPIChangeItems(changes.Removed),
+		})
+	})
+
+	api.ApIsIndividualGetApisNamespaceNameHandler = a_p_is_individual.GetApisNamespaceNameHandlerFunc(
+		func(params a_p_is_individual.GetApisNamespaceNameParams, principal *models.Principal) middleware.Responder {
+			api := &wso2v1alpha2.API{}
+			err := k8sClient.Get(params.HTTPRequest.Context(),
+				types.NamespacedName{Namespace: params.Namespace, Name: params.Name}, api)
+			if err != nil {
+				if k8serrors.IsNotFound(err) {
+					return a_p_is_individual.NewGetApisNamespaceNameNotFound().WithPayload(&models.Error{
+						Code:    swag.Int64(http.StatusNotFound),
+						Message: swag.String("API not found"),
+					})
+				}
+				logRestServer.Error(err, "Error reading the API", "namespace", params.Namespace, "name", params.Name)
+				return a_p_is_individual.NewGetApisNamespaceNameInternalServerError().WithPayload(&models.Error{
+					Code:    swag.Int64(http.StatusInternalServerError),
+					Message: swag.String("Error reading the API"),
+				})
+			}
+			return a_p_is_individual.NewGetApisNamespaceNameOK().WithPayload(toAPIInfo(api, envoy.GetSnapshot()))
+		})
+
+	api.ApIsIndividualGetApisNamespaceNameArtifactHandler = a_p_is_individual.GetApisNamespaceNameArtifactHandlerFunc(
+		func(params a_p_is_individual.GetApisNamespaceNameArtifactParams, principal *models.Principal) middleware.Responder {
+			snapshot := envoy.GetSnapshot()
+			if snapshot != nil {
+				if artifact, ok := snapshot.GetArtifact(params.Namespace, params.Name); ok {
+					return a_p_is_individual.NewGetApisNamespaceNameArtifactOK().
+						WithXOperatorRevision(int64(snapshot.Revision)).
+						WithPayload(ioutil.NopCloser(bytes.NewReader(artifact)))
+				}
+			}
+			return a_p_is_individual.NewGetApisNamespaceNameArtifactNotFound().WithPayload(&models.Error{
+				Code:    swag.Int64(http.StatusNotFound),
+				Message: swag.String("API not found in the API bundle snapshot"),
+			})
 		})
-	}
 
 	api.PreServerShutdown = func() {}
 
@@ -107,24 +178,82 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// eTagMatches returns true if the value of the If-None-Match header matches the given entity tag.
+func eTagMatches(ifNoneMatch, eTag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == eTag {
+			return true
+		}
+	}
+	return false
+}
+
+// getAPIInfoList responds with the APIs matching the query params of the get apis operation as JSON
+func getAPIInfoList(params a_p_is_all.GetApisParams, snapshot *envoy.Snapshot) middleware.Responder {
+	selector := labels.Everything()
+	if params.LabelSelector != nil {
+		var err error
+		if selector, err = labels.Parse(*params.LabelSelector); err != nil {
+			return a_p_is_all.NewGetApisBadRequest().WithPayload(&models.Error{
+				Code:        swag.Int64(http.StatusBadRequest),
+				Message:     swag.String("Invalid label selector"),
+				Description: err.Error(),
+			})
+		}
+	}
+	namespace := ""
+	if params.Namespace != nil {
+		namespace = *params.Namespace
+	}
+
+	infoList, err := listAPIInfo(params.HTTPRequest.Context(), namespace, selector, *params.Offset, *params.Limit,
+		snapshot)
+	if err != nil {
+		logRestServer.Error(err, "Error listing the APIs")
+		return a_p_is_all.NewGetApisInternalServerError().WithPayload(&models.Error{
+			Code:    swag.Int64(http.StatusInternalServerError),
+			Message: swag.String("Error listing the APIs"),
+		})
+	}
+	// the operation produces the zip bundle by default, hence write the JSON without the negotiated producer
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		rw.Header().Set(runtime.HeaderContentType, runtime.JSONMime)
+		rw.WriteHeader(http.StatusOK)
+		if err := runtime.JSONProducer().Produce(rw, infoList); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	})
+}
+
+// toAPIChangeItems converts the given snapshot entries to the API change items of the response
+func toAPIChangeItems(entries []envoy.SnapshotEntry) []*models.APIChangeItem {
+	items := make([]*models.APIChangeItem, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, &models.APIChangeItem{
+			Namespace: swag.String(entry.Namespace),
+			Name:      swag.String(entry.Name),
+			Digest:    entry.Digest,
+		})
+	}
+	return items
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
 
-	tlsConfig.Certificates, _ = getCertificates("/home/wso2/security/tls.crt", "/home/wso2/security/tls.key")
-}
+	tlsConfig.MinVersion = tlsConf.minVersion
+	if len(tlsConf.cipherSuites) != 0 {
+		tlsConfig.CipherSuites = tlsConf.cipherSuites
+	}
+	// Serve the latest certificate, so that the rotated certificates are served without a restart
+	tlsConfig.GetCertificate = certLoader.GetCertificate
 
-func getCertificates(publicKeyPath, privateKeyPath string) ([]tls.Certificate, error) {
-	certificates := make([]tls.Certificate, 1)
-	tlsCertificate := publicKeyPath
-	tlsCertificateKey := privateKeyPath
-	certificate, err := tls.LoadX509KeyPair(tlsCertificate, tlsCertificateKey)
-	if err != nil {
-		logRestServer.Error(err, "Error while loading key pair")
-		return nil, err
+	// Require client certs if the mTLS auth mode is configured
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return getClientTLSConfig(tlsConfig)
 	}
-	certificates[0] = certificate
-	return certificates, nil
 }
 
 // As soon as server is initialized but not run yet, this function will be called.
@@ -145,45 +274,3 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
 	return handler
 }
-
-func StartRestServer() {
-	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
-	if err != nil {
-		log.Fatalln(err)
-	}
-
-	api := operations.NewRestapiAPI(swaggerSpec)
-	server := NewServer(api)
-	defer server.Shutdown()
-
-	parser := flags.NewParser(server, flags.Default)
-	parser.ShortDescription = "Internal Utility API"
-	parser.LongDescription = "This document specifies a **RESTful API** for allowing you to access internal data " +
-		".\nPlease see [full swagger definition](https://raw.githubusercontent.com/wso2/carbon-apimgt/master/components/apimgt/org.wso2.carbon.apimgt.internal.service/src/main/resources/api.yaml) " +
-		"of the API which is written using [swagger 2.0](http://swagger.io/) specification.\n"
-	server.ConfigureFlags()
-	for _, optsGroup := range api.CommandLineOptionsGroups {
-		_, err := parser.AddGroup(optsGroup.ShortDescription, optsGroup.LongDescription, optsGroup.Options)
-		if err != nil {
-			log.Fatalln(err)
-		}
-	}
-
-	if _, err := parser.Parse(); err != nil {
-		code := 1
-		if fe, ok := err.(*flags.Error); ok {
-			if fe.Type == flags.ErrHelp {
-				code = 0
-			}
-		}
-		os.Exit(code)
-	}
-
-	server.ConfigureAPI()
-	server.TLSHost = "0.0.0.0"
-	server.TLSPort = 9445
-
-	if err := server.Serve(); err != nil {
-		log.Fatalln(err)
-	}
-}
@@ -0,0 +1,54 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import "time"
+
+const (
+	restServerConfName          = "operator-rest-server-config"
+	authModeConst               = "authMode"
+	basicAuthSecretConst        = "basicAuthSecret"
+	allowedServiceAccountsConst = "allowedServiceAccounts"
+	clientCASecretConst         = "clientCASecret"
+	tlsPortConst                = "tlsPort"
+	tlsCertFileConst            = "tlsCertFile"
+	tlsKeyFileConst             = "tlsKeyFile"
+	tlsSecretConst              = "tlsSecret"
+	tlsMinVersionConst          = "tlsMinVersion"
+	tlsCipherSuitesConst        = "tlsCipherSuites"
+
+	authModeBasic  = "basic"
+	authModeBearer = "bearer"
+	authModeMTLS   = "mtls"
+
+	defaultBasicAuthSecretName = "operator-rest-server-credentials"
+	usernameConst              = "username"
+	passwordConst              = "password"
+	caCertConst                = "ca.crt"
+	defaultTLSPort             = 9445
+	defaultTLSCertFile         = "/home/wso2/security/tls.crt"
+	defaultTLSKeyFile          = "/home/wso2/security/tls.key"
+
+	headerAuthorization         = "Authorization"
+	headerValueAuthBearerPrefix = "Bearer"
+	serviceAccountUserPrefix    = "system:serviceaccount:"
+	tokenReviewTTL              = time.Minute
+	// maxLongPollTimeoutSeconds is the maximum timeout of the long-polls of the API changes in the API definition
+	maxLongPollTimeoutSeconds = 55
+
+	formatJSON = "json"
+)
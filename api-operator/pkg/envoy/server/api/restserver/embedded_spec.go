@@ -52,20 +52,102 @@ func init() {
             "BasicAuth": []
           }
         ],
-        "description": "This operation can be used to get all the APIs deployed in Kubernetes.\n",
+        "description": "This operation can be used to get all the APIs deployed in Kubernetes.\nReturns the API bundle snapshot as a zip file, or the APIs as an APIInfoList JSON if the format is json.\n",
         "produces": [
-          "application/zip"
+          "application/zip",
+          "application/json"
         ],
         "tags": [
           "APIs (All)"
         ],
         "summary": "Get all apis in a zip file",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Entity tag of the API bundle snapshot that the client already has.\n",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "enum": [
+              "zip",
+              "json"
+            ],
+            "type": "string",
+            "default": "zip",
+            "description": "Format of the response.\n",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Label selector to filter the APIs, applies to the json format.\n",
+            "name": "labelSelector",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Namespace to filter the APIs, applies to the json format.\n",
+            "name": "namespace",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of APIs to return, applies to the json format.\n",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Number of APIs to skip, applies to the json format.\n",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Sent.\nAPIs sent Successfully.\n",
             "schema": {
               "type": "string",
               "format": "binary"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "Entity tag of the API bundle snapshot.\n"
+              },
+              "X-Operator-Revision": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Revision of the API bundle snapshot.\n"
+              }
+            }
+          },
+          "304": {
+            "description": "Not Modified.\nAPI bundle snapshot is not changed since the given entity tag.\n",
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "Entity tag of the API bundle snapshot.\n"
+              },
+              "X-Operator-Revision": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Revision of the API bundle snapshot.\n"
+              }
+            }
+          },
+          "400": {
+            "description": "Bad Request.\nInvalid label selector.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
             }
           },
           "403": {
@@ -88,9 +170,326 @@ func init() {
           }
         }
       }
+    },
+    "/apis/changes": {
+      "get": {
+        "security": [
+          {
+            "BasicAuth": []
+          }
+        ],
+        "description": "This operation can be used to wait for the changes of the APIs deployed in Kubernetes.\nBlocks until the revision of the API bundle snapshot moves past the given revision or the timeout is reached.\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "APIs (All)"
+        ],
+        "summary": "Get changes of the apis",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Revision of the API bundle snapshot that the client already has.\n",
+            "name": "revision",
+            "in": "query"
+          },
+          {
+            "maximum": 55,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 30,
+            "description": "Maximum duration to wait for the changes in seconds.\n",
+            "name": "timeout",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK.\nChanges of the APIs since the given revision.\n",
+            "schema": {
+              "$ref": "#/definitions/APIChanges"
+            }
+          },
+          "403": {
+            "description": "Forbidden\nNot Authorized to send.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error.\nError in sending changes of the APIs.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/apis/{namespace}/{name}": {
+      "get": {
+        "security": [
+          {
+            "BasicAuth": []
+          }
+        ],
+        "description": "This operation can be used to get the metadata of an API deployed in Kubernetes.\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "APIs (Individual)"
+        ],
+        "summary": "Get an api",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Namespace of the API.\n",
+            "name": "namespace",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the API.\n",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK.\nRequested API is returned.\n",
+            "schema": {
+              "$ref": "#/definitions/APIInfo"
+            }
+          },
+          "403": {
+            "description": "Forbidden\nNot Authorized to send.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Not Found.\nRequested API not found.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error.\nError in sending the API.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/apis/{namespace}/{name}/artifact": {
+      "get": {
+        "security": [
+          {
+            "BasicAuth": []
+          }
+        ],
+        "description": "This operation can be used to get the project zip of an API in the API bundle snapshot.\n",
+        "produces": [
+          "application/zip"
+        ],
+        "tags": [
+          "APIs (Individual)"
+        ],
+        "summary": "Get the artifact of an api",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Namespace of the API.\n",
+            "name": "namespace",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the API.\n",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Sent.\nProject zip of the API sent Successfully.\n",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            },
+            "headers": {
+              "X-Operator-Revision": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Revision of the API bundle snapshot.\n"
+              }
+            }
+          },
+          "403": {
+            "description": "Forbidden\nNot Authorized to send.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Not Found.\nRequested API not found in the API bundle snapshot.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error.\nError in sending the API.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "APIChangeItem": {
+      "title": "API changed in the API bundle snapshot",
+      "required": [
+        "namespace",
+        "name"
+      ],
+      "properties": {
+        "digest": {
+          "description": "Digest of the API definition",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the API",
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Namespace of the API",
+          "type": "string"
+        }
+      }
+    },
+    "APIChanges": {
+      "title": "Changes of the APIs since a revision of the API bundle snapshot",
+      "required": [
+        "revision"
+      ],
+      "properties": {
+        "added": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIChangeItem"
+          }
+        },
+        "fullResync": {
+          "description": "True if the given revision is unknown, and all the APIs are listed as added.\n",
+          "type": "boolean"
+        },
+        "removed": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIChangeItem"
+          }
+        },
+        "revision": {
+          "description": "Current revision of the API bundle snapshot",
+          "type": "integer",
+          "format": "int64"
+        },
+        "updated": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIChangeItem"
+          }
+        }
+      }
+    },
+    "APIInfo": {
+      "title": "Metadata of an API",
+      "required": [
+        "namespace",
+        "name"
+      ],
+      "properties": {
+        "apiName": {
+          "description": "Name of the API in the API definition",
+          "type": "string"
+        },
+        "basePath": {
+          "description": "Base path of the API",
+          "type": "string"
+        },
+        "digest": {
+          "description": "Digest of the API definition in the API bundle snapshot",
+          "type": "string"
+        },
+        "mode": {
+          "description": "Deployment mode of the API",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the API",
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Namespace of the API",
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the API bundle snapshot the API definition last changed",
+          "type": "integer",
+          "format": "int64"
+        },
+        "version": {
+          "description": "Version of the API",
+          "type": "string"
+        }
+      }
+    },
+    "APIInfoList": {
+      "title": "List of APIs",
+      "required": [
+        "count",
+        "total"
+      ],
+      "properties": {
+        "count": {
+          "description": "Number of APIs in the list",
+          "type": "integer",
+          "format": "int64"
+        },
+        "list": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIInfo"
+          }
+        },
+        "offset": {
+          "description": "Number of APIs skipped",
+          "type": "integer",
+          "format": "int64"
+        },
+        "revision": {
+          "description": "Current revision of the API bundle snapshot",
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "description": "Total number of APIs matching the filters",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "Error": {
       "title": "Error object returned with 4XX HTTP status",
       "required": [
@@ -197,20 +596,102 @@ func init() {
             "BasicAuth": []
           }
         ],
-        "description": "This operation can be used to get all the APIs deployed in Kubernetes.\n",
+        "description": "This operation can be used to get all the APIs deployed in Kubernetes.\nReturns the API bundle snapshot as a zip file, or the APIs as an APIInfoList JSON if the format is json.\n",
         "produces": [
-          "application/zip"
+          "application/zip",
+          "application/json"
         ],
         "tags": [
           "APIs (All)"
         ],
         "summary": "Get all apis in a zip file",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Entity tag of the API bundle snapshot that the client already has.\n",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "enum": [
+              "zip",
+              "json"
+            ],
+            "type": "string",
+            "default": "zip",
+            "description": "Format of the response.\n",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Label selector to filter the APIs, applies to the json format.\n",
+            "name": "labelSelector",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Namespace to filter the APIs, applies to the json format.\n",
+            "name": "namespace",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of APIs to return, applies to the json format.\n",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Number of APIs to skip, applies to the json format.\n",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Sent.\nAPIs sent Successfully.\n",
             "schema": {
               "type": "string",
               "format": "binary"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "Entity tag of the API bundle snapshot.\n"
+              },
+              "X-Operator-Revision": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Revision of the API bundle snapshot.\n"
+              }
+            }
+          },
+          "304": {
+            "description": "Not Modified.\nAPI bundle snapshot is not changed since the given entity tag.\n",
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "Entity tag of the API bundle snapshot.\n"
+              },
+              "X-Operator-Revision": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Revision of the API bundle snapshot.\n"
+              }
+            }
+          },
+          "400": {
+            "description": "Bad Request.\nInvalid label selector.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
             }
           },
           "403": {
@@ -233,9 +714,326 @@ func init() {
           }
         }
       }
+    },
+    "/apis/changes": {
+      "get": {
+        "security": [
+          {
+            "BasicAuth": []
+          }
+        ],
+        "description": "This operation can be used to wait for the changes of the APIs deployed in Kubernetes.\nBlocks until the revision of the API bundle snapshot moves past the given revision or the timeout is reached.\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "APIs (All)"
+        ],
+        "summary": "Get changes of the apis",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Revision of the API bundle snapshot that the client already has.\n",
+            "name": "revision",
+            "in": "query"
+          },
+          {
+            "maximum": 55,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 30,
+            "description": "Maximum duration to wait for the changes in seconds.\n",
+            "name": "timeout",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK.\nChanges of the APIs since the given revision.\n",
+            "schema": {
+              "$ref": "#/definitions/APIChanges"
+            }
+          },
+          "403": {
+            "description": "Forbidden\nNot Authorized to send.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error.\nError in sending changes of the APIs.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/apis/{namespace}/{name}": {
+      "get": {
+        "security": [
+          {
+            "BasicAuth": []
+          }
+        ],
+        "description": "This operation can be used to get the metadata of an API deployed in Kubernetes.\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "APIs (Individual)"
+        ],
+        "summary": "Get an api",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Namespace of the API.\n",
+            "name": "namespace",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the API.\n",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK.\nRequested API is returned.\n",
+            "schema": {
+              "$ref": "#/definitions/APIInfo"
+            }
+          },
+          "403": {
+            "description": "Forbidden\nNot Authorized to send.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Not Found.\nRequested API not found.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error.\nError in sending the API.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/apis/{namespace}/{name}/artifact": {
+      "get": {
+        "security": [
+          {
+            "BasicAuth": []
+          }
+        ],
+        "description": "This operation can be used to get the project zip of an API in the API bundle snapshot.\n",
+        "produces": [
+          "application/zip"
+        ],
+        "tags": [
+          "APIs (Individual)"
+        ],
+        "summary": "Get the artifact of an api",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Namespace of the API.\n",
+            "name": "namespace",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the API.\n",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Sent.\nProject zip of the API sent Successfully.\n",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            },
+            "headers": {
+              "X-Operator-Revision": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Revision of the API bundle snapshot.\n"
+              }
+            }
+          },
+          "403": {
+            "description": "Forbidden\nNot Authorized to send.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Not Found.\nRequested API not found in the API bundle snapshot.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error.\nError in sending the API.\n",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "APIChangeItem": {
+      "title": "API changed in the API bundle snapshot",
+      "required": [
+        "namespace",
+        "name"
+      ],
+      "properties": {
+        "digest": {
+          "description": "Digest of the API definition",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the API",
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Namespace of the API",
+          "type": "string"
+        }
+      }
+    },
+    "APIChanges": {
+      "title": "Changes of the APIs since a revision of the API bundle snapshot",
+      "required": [
+        "revision"
+      ],
+      "properties": {
+        "added": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIChangeItem"
+          }
+        },
+        "fullResync": {
+          "description": "True if the given revision is unknown, and all the APIs are listed as added.\n",
+          "type": "boolean"
+        },
+        "removed": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIChangeItem"
+          }
+        },
+        "revision": {
+          "description": "Current revision of the API bundle snapshot",
+          "type": "integer",
+          "format": "int64"
+        },
+        "updated": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIChangeItem"
+          }
+        }
+      }
+    },
+    "APIInfo": {
+      "title": "Metadata of an API",
+      "required": [
+        "namespace",
+        "name"
+      ],
+      "properties": {
+        "apiName": {
+          "description": "Name of the API in the API definition",
+          "type": "string"
+        },
+        "basePath": {
+          "description": "Base path of the API",
+          "type": "string"
+        },
+        "digest": {
+          "description": "Digest of the API definition in the API bundle snapshot",
+          "type": "string"
+        },
+        "mode": {
+          "description": "Deployment mode of the API",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the API",
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Namespace of the API",
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the API bundle snapshot the API definition last changed",
+          "type": "integer",
+          "format": "int64"
+        },
+        "version": {
+          "description": "Version of the API",
+          "type": "string"
+        }
+      }
+    },
+    "APIInfoList": {
+      "title": "List of APIs",
+      "required": [
+        "count",
+        "total"
+      ],
+      "properties": {
+        "count": {
+          "description": "Number of APIs in the list",
+          "type": "integer",
+          "format": "int64"
+        },
+        "list": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/APIInfo"
+          }
+        },
+        "offset": {
+          "description": "Number of APIs skipped",
+          "type": "integer",
+          "format": "int64"
+        },
+        "revision": {
+          "description": "Current revision of the API bundle snapshot",
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "description": "Total number of APIs matching the filters",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "Error": {
       "title": "Error object returned with 4XX HTTP status",
       "required": [
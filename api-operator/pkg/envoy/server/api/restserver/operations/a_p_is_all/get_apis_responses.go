@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/models"
 )
@@ -24,6 +25,14 @@ APIs sent Successfully.
 swagger:response getApisOK
 */
 type GetApisOK struct {
+	/*Entity tag of the API bundle snapshot.
+
+	 */
+	ETag string `json:"ETag"`
+	/*Revision of the API bundle snapshot.
+
+	 */
+	XOperatorRevision int64 `json:"X-Operator-Revision"`
 
 	/*
 	  In: Body
@@ -37,6 +46,28 @@ func NewGetApisOK() *GetApisOK {
 	return &GetApisOK{}
 }
 
+// WithETag adds the eTag to the get apis o k response
+func (o *GetApisOK) WithETag(eTag string) *GetApisOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get apis o k response
+func (o *GetApisOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
+// WithXOperatorRevision adds the xOperatorRevision to the get apis o k response
+func (o *GetApisOK) WithXOperatorRevision(xOperatorRevision int64) *GetApisOK {
+	o.XOperatorRevision = xOperatorRevision
+	return o
+}
+
+// SetXOperatorRevision sets the xOperatorRevision to the get apis o k response
+func (o *GetApisOK) SetXOperatorRevision(xOperatorRevision int64) {
+	o.XOperatorRevision = xOperatorRevision
+}
+
 // WithPayload adds the payload to the get apis o k response
 func (o *GetApisOK) WithPayload(payload io.ReadCloser) *GetApisOK {
 	o.Payload = payload
@@ -51,6 +82,20 @@ func (o *GetApisOK) SetPayload(payload io.ReadCloser) {
 // WriteResponse to the client
 func (o *GetApisOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
+	// response header X-Operator-Revision
+
+	xOperatorRevision := swag.FormatInt64(o.XOperatorRevision)
+	if xOperatorRevision != "" {
+		rw.Header().Set("X-Operator-Revision", xOperatorRevision)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if err := producer.Produce(rw, payload); err != nil {
@@ -58,6 +103,122 @@ func (o *GetApisOK) WriteResponse(rw http.ResponseWriter, producer runtime.Produ
 	}
 }
 
+// GetApisNotModifiedCode is the HTTP code returned for type GetApisNotModified
+const GetApisNotModifiedCode int = 304
+
+/*GetApisNotModified Not Modified.
+API bundle snapshot is not changed since the given entity tag.
+
+
+swagger:response getApisNotModified
+*/
+type GetApisNotModified struct {
+	/*Entity tag of the API bundle snapshot.
+
+	 */
+	ETag string `json:"ETag"`
+	/*Revision of the API bundle snapshot.
+
+	 */
+	XOperatorRevision int64 `json:"X-Operator-Revision"`
+}
+
+// NewGetApisNotModified creates GetApisNotModified with default headers values
+func NewGetApisNotModified() *GetApisNotModified {
+
+	return &GetApisNotModified{}
+}
+
+// WithETag adds the eTag to the get apis not modified response
+func (o *GetApisNotModified) WithETag(eTag string) *GetApisNotModified {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get apis not modified response
+func (o *GetApisNotModified) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
+// WithXOperatorRevision adds the xOperatorRevision to the get apis not modified response
+func (o *GetApisNotModified) WithXOperatorRevision(xOperatorRevision int64) *GetApisNotModified {
+	o.XOperatorRevision = xOperatorRevision
+	return o
+}
+
+// SetXOperatorRevision sets the xOperatorRevision to the get apis not modified response
+func (o *GetApisNotModified) SetXOperatorRevision(xOperatorRevision int64) {
+	o.XOperatorRevision = xOperatorRevision
+}
+
+// WriteResponse to the client
+func (o *GetApisNotModified) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
+	// response header X-Operator-Revision
+
+	xOperatorRevision := swag.FormatInt64(o.XOperatorRevision)
+	if xOperatorRevision != "" {
+		rw.Header().Set("X-Operator-Revision", xOperatorRevision)
+	}
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(304)
+}
+
+// GetApisBadRequestCode is the HTTP code returned for type GetApisBadRequest
+const GetApisBadRequestCode int = 400
+
+/*GetApisBadRequest Bad Request.
+Invalid label selector.
+
+
+swagger:response getApisBadRequest
+*/
+type GetApisBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetApisBadRequest creates GetApisBadRequest with default headers values
+func NewGetApisBadRequest() *GetApisBadRequest {
+
+	return &GetApisBadRequest{}
+}
+
+// WithPayload adds the payload to the get apis bad request response
+func (o *GetApisBadRequest) WithPayload(payload *models.Error) *GetApisBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get apis bad request response
+func (o *GetApisBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetApisBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // GetApisForbiddenCode is the HTTP code returned for type GetApisForbidden
 const GetApisForbiddenCode int = 403
 
@@ -22,6 +22,7 @@ import (
 
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/models"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/restserver/operations/a_p_is_all"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/restserver/operations/a_p_is_individual"
 )
 
 // NewRestapiAPI creates a new Restapi instance
@@ -44,6 +45,7 @@ func NewRestapiAPI(spec *loads.Document) *RestapiAPI {
 
 		JSONConsumer: runtime.JSONConsumer(),
 
+		JSONProducer: runtime.JSONProducer(),
 		ApplicationZipProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
 			return errors.NotImplemented("applicationZip producer has not yet been implemented")
 		}),
@@ -51,6 +53,15 @@ func NewRestapiAPI(spec *loads.Document) *RestapiAPI {
 		ApIsAllGetApisHandler: a_p_is_all.GetApisHandlerFunc(func(params a_p_is_all.GetApisParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation a_p_is_all.GetApis has not yet been implemented")
 		}),
+		ApIsAllGetApisChangesHandler: a_p_is_all.GetApisChangesHandlerFunc(func(params a_p_is_all.GetApisChangesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation a_p_is_all.GetApisChanges has not yet been implemented")
+		}),
+		ApIsIndividualGetApisNamespaceNameHandler: a_p_is_individual.GetApisNamespaceNameHandlerFunc(func(params a_p_is_individual.GetApisNamespaceNameParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation a_p_is_individual.GetApisNamespaceName has not yet been implemented")
+		}),
+		ApIsIndividualGetApisNamespaceNameArtifactHandler: a_p_is_individual.GetApisNamespaceNameArtifactHandlerFunc(func(params a_p_is_individual.GetApisNamespaceNameArtifactParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation a_p_is_individual.GetApisNamespaceNameArtifact has not yet been implemented")
+		}),
 
 		// Applies when the Authorization header is set with the Basic scheme
 		BasicAuthAuth: func(user string, pass string) (*models.Principal, error) {
@@ -90,6 +101,9 @@ type RestapiAPI struct {
 	//   - application/json
 	JSONConsumer runtime.Consumer
 
+	// JSONProducer registers a producer for the following mime types:
+	//   - application/json
+	JSONProducer runtime.Producer
 	// ApplicationZipProducer registers a producer for the following mime types:
 	//   - application/zip
 	ApplicationZipProducer runtime.Producer
@@ -103,6 +117,12 @@ type RestapiAPI struct {
 
 	// ApIsAllGetApisHandler sets the operation handler for the get apis operation
 	ApIsAllGetApisHandler a_p_is_all.GetApisHandler
+	// ApIsAllGetApisChangesHandler sets the operation handler for the get apis changes operation
+	ApIsAllGetApisChangesHandler a_p_is_all.GetApisChangesHandler
+	// ApIsIndividualGetApisNamespaceNameHandler sets the operation handler for the get apis namespace name operation
+	ApIsIndividualGetApisNamespaceNameHandler a_p_is_individual.GetApisNamespaceNameHandler
+	// ApIsIndividualGetApisNamespaceNameArtifactHandler sets the operation handler for the get apis namespace name artifact operation
+	ApIsIndividualGetApisNamespaceNameArtifactHandler a_p_is_individual.GetApisNamespaceNameArtifactHandler
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
 	ServeError func(http.ResponseWriter, *http.Request, error)
@@ -175,6 +195,9 @@ func (o *RestapiAPI) Validate() error {
 		unregistered = append(unregistered, "JSONConsumer")
 	}
 
+	if o.JSONProducer == nil {
+		unregistered = append(unregistered, "JSONProducer")
+	}
 	if o.ApplicationZipProducer == nil {
 		unregistered = append(unregistered, "ApplicationZipProducer")
 	}
@@ -186,6 +209,15 @@ func (o *RestapiAPI) Validate() error {
 	if o.ApIsAllGetApisHandler == nil {
 		unregistered = append(unregistered, "a_p_is_all.GetApisHandler")
 	}
+	if o.ApIsAllGetApisChangesHandler == nil {
+		unregistered = append(unregistered, "a_p_is_all.GetApisChangesHandler")
+	}
+	if o.ApIsIndividualGetApisNamespaceNameHandler == nil {
+		unregistered = append(unregistered, "a_p_is_individual.GetApisNamespaceNameHandler")
+	}
+	if o.ApIsIndividualGetApisNamespaceNameArtifactHandler == nil {
+		unregistered = append(unregistered, "a_p_is_individual.GetApisNamespaceNameArtifactHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -242,6 +274,8 @@ func (o *RestapiAPI) ProducersFor(mediaTypes []string) map[string]runtime.Produc
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/json":
+			result["application/json"] = o.JSONProducer
 		case "application/zip":
 			result["application/zip"] = o.ApplicationZipProducer
 		}
@@ -288,6 +322,18 @@ func (o *RestapiAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/apis"] = a_p_is_all.NewGetApis(o.context, o.ApIsAllGetApisHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/changes"] = a_p_is_all.NewGetApisChanges(o.context, o.ApIsAllGetApisChangesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/{namespace}/{name}"] = a_p_is_individual.NewGetApisNamespaceName(o.context, o.ApIsIndividualGetApisNamespaceNameHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/{namespace}/{name}/artifact"] = a_p_is_individual.NewGetApisNamespaceNameArtifact(o.context, o.ApIsIndividualGetApisNamespaceNameArtifactHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
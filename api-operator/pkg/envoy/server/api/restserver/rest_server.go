@@ -0,0 +1,128 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime/flagext"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/envoy/server/api/restserver/operations"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync/atomic"
+	"time"
+)
+
+// longPollStop is the stop channel of the running rest server which ends the in-flight long-polls
+var longPollStop <-chan struct{}
+
+// RestServer serves the APIs to the MGW Adapter. It is added to the manager as a Runnable, so that it is started
+// after the caches are synced and shutdown gracefully when the manager is stopped.
+type RestServer struct {
+	// apiReader reads the configurations at the startup without the cache
+	apiReader client.Reader
+	// listening is 1 while the server is listening for the requests
+	listening int32
+}
+
+// NewRestServer returns a rest server that reads the configurations with the given client
+func NewRestServer(client client.Client, apiReader client.Reader) *RestServer {
+	k8sClient = client
+	return &RestServer{apiReader: apiReader}
+}
+
+// Start starts the rest server and blocks until the stop channel is closed and the server is shutdown
+func (r *RestServer) Start(stop <-chan struct{}) error {
+	var err error
+	tlsConf, err = getTLSServerConfig(context.Background(), r.apiReader)
+	if err != nil {
+		return fmt.Errorf("invalid TLS configuration of the rest server in the configmap %q: %v", restServerConfName, err)
+	}
+	certLoader = newCertificateLoader(tlsConf)
+	if err := certLoader.reload(context.Background(), r.apiReader); err != nil {
+		return fmt.Errorf("error loading the TLS certificate of the rest server: %v", err)
+	}
+
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		return err
+	}
+	server := newServer(operations.NewRestapiAPI(swaggerSpec))
+	server.ConfigureAPI()
+	server.TLSHost = "0.0.0.0"
+	server.TLSPort = tlsConf.port
+	if err := server.Listen(); err != nil {
+		return err
+	}
+
+	atomic.StoreInt32(&r.listening, 1)
+	defer atomic.StoreInt32(&r.listening, 0)
+	longPollStop = stop
+	go func() {
+		<-stop
+		// in-flight long-polls are ended with the stop channel, so that they do not hold the graceful shutdown
+		logRestServer.Info("Shutting down the rest server")
+		_ = server.Shutdown()
+	}()
+	if err := server.Serve(); err != nil {
+		return fmt.Errorf("error serving the rest server: %v", err)
+	}
+	return nil
+}
+
+// withLongPollStop returns a copy of the given context which is also cancelled when the rest server is stopped
+func withLongPollStop(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := longPollStop
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// ReadyzCheck fails until the rest server is listening and the first snapshot of the API bundle is built
+func (r *RestServer) ReadyzCheck(_ *http.Request) error {
+	if atomic.LoadInt32(&r.listening) == 0 {
+		return errors.New("rest server is not listening")
+	}
+	if envoy.GetSnapshot() == nil {
+		return errors.New("API bundle snapshot is not built yet")
+	}
+	return nil
+}
+
+// newServer returns a server with the default options of the command line flags of the server, since the flags
+// are not parsed to avoid conflicts with the flags of the operator
+func newServer(api *operations.RestapiAPI) *Server {
+	server := NewServer(api)
+	server.CleanupTimeout = 10 * time.Second
+	server.GracefulTimeout = 15 * time.Second
+	server.MaxHeaderSize = flagext.ByteSize(1024 * 1024)
+	// the server listens only on HTTPS, the HTTP options are not used
+	server.TLSKeepAlive = 3 * time.Minute
+	server.TLSReadTimeout = 30 * time.Second
+	// long-polls of the API changes are held for up to the maximum timeout of the request
+	server.TLSWriteTimeout = time.Duration(maxLongPollTimeoutSeconds)*time.Second + 30*time.Second
+	return server
+}
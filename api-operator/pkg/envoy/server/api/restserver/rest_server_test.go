@@ -0,0 +1,48 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewServer(t *testing.T) {
+	server := newServer(nil)
+	if server.TLSKeepAlive <= 0 || server.TLSReadTimeout <= 0 {
+		t.Error("keep-alive and read timeout of the TLS listener should be set")
+	}
+	if server.TLSWriteTimeout <= maxLongPollTimeoutSeconds*time.Second {
+		t.Errorf("write timeout should be above the maximum long-poll timeout, was %v", server.TLSWriteTimeout)
+	}
+}
+
+func TestWithLongPollStop(t *testing.T) {
+	stop := make(chan struct{})
+	longPollStop = stop
+	defer func() { longPollStop = nil }()
+
+	ctx, cancel := withLongPollStop(context.Background())
+	defer cancel()
+	close(stop)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("long-poll should be ended when the rest server is stopped")
+	}
+}
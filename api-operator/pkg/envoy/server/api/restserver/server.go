@@ -169,6 +169,15 @@ func (s *Server) Serve() (err error) {
 
 	wg := new(sync.WaitGroup)
 	once := new(sync.Once)
+	// the first error serving a listener is returned after the other listeners are shutdown
+	var serveErr error
+	var serveErrOnce sync.Once
+	failServe := func(err error) {
+		serveErrOnce.Do(func() {
+			serveErr = err
+			_ = s.Shutdown()
+		})
+	}
 	signalNotify(s.interrupt)
 	go handleInterrupt(once, s)
 
@@ -190,7 +199,7 @@ func (s *Server) Serve() (err error) {
 		go func(l net.Listener) {
 			defer wg.Done()
 			if err := domainSocket.Serve(l); err != nil && err != http.ErrServerClosed {
-				s.Fatalf("%v", err)
+				failServe(err)
 			}
 			s.Logf("Stopped serving restapi at unix://%s", s.SocketPath)
 		}(s.domainSocketL)
@@ -220,7 +229,7 @@ func (s *Server) Serve() (err error) {
 		go func(l net.Listener) {
 			defer wg.Done()
 			if err := httpServer.Serve(l); err != nil && err != http.ErrServerClosed {
-				s.Fatalf("%v", err)
+				failServe(err)
 			}
 			s.Logf("Stopped serving restapi at http://%s", l.Addr())
 		}(s.httpServerL)
@@ -294,15 +303,15 @@ func (s *Server) Serve() (err error) {
 			// after standard and custom config are passed, this ends up with no certificate
 			if s.TLSCertificate == "" {
 				if s.TLSCertificateKey == "" {
-					s.Fatalf("the required flags `--tls-certificate` and `--tls-key` were not specified")
+					return errors.New("the required flags `--tls-certificate` and `--tls-key` were not specified")
 				}
-				s.Fatalf("the required flag `--tls-certificate` was not specified")
+				return errors.New("the required flag `--tls-certificate` was not specified")
 			}
 			if s.TLSCertificateKey == "" {
-				s.Fatalf("the required flag `--tls-key` was not specified")
+				return errors.New("the required flag `--tls-key` was not specified")
 			}
 			// this happens with a wrong custom TLS configurator
-			s.Fatalf("no certificate was configured for TLS")
+			return errors.New("no certificate was configured for TLS")
 		}
 
 		// must have at least one certificate or panics
@@ -316,7 +325,7 @@ func (s *Server) Serve() (err error) {
 		go func(l net.Listener) {
 			defer wg.Done()
 			if err := httpsServer.Serve(l); err != nil && err != http.ErrServerClosed {
-				s.Fatalf("%v", err)
+				failServe(err)
 			}
 			s.Logf("Stopped serving restapi at https://%s", l.Addr())
 		}(tls.NewListener(s.httpsServerL, httpsServer.TLSConfig))
@@ -326,7 +335,7 @@ func (s *Server) Serve() (err error) {
 	go s.handleShutdown(wg, &servers)
 
 	wg.Wait()
-	return nil
+	return serveErr
 }
 
 // Listen creates the listeners for the server
@@ -0,0 +1,206 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// tlsVersions are the supported minimum TLS versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// cipherSuites are the supported cipher suites for TLS 1.2 and below
+var cipherSuites = map[string]uint16{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":    tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// tlsServerConfig is the TLS configuration of the rest server
+type tlsServerConfig struct {
+	port     int
+	certFile string
+	keyFile  string
+	// secret is the name of the TLS secret to load the cert and key, instead of the files
+	secret       string
+	minVersion   uint16
+	cipherSuites []uint16
+}
+
+// getTLSServerConfig returns the TLS configuration from the rest server configmap in the system namespace
+func getTLSServerConfig(ctx context.Context, reader client.Reader) (*tlsServerConfig, error) {
+	tlsConf := &tlsServerConfig{
+		port:       defaultTLSPort,
+		certFile:   defaultTLSCertFile,
+		keyFile:    defaultTLSKeyFile,
+		minVersion: tls.VersionTLS12,
+	}
+	confMap, err := getRestServerConfMap(ctx, reader)
+	if err != nil || confMap == nil {
+		return tlsConf, err
+	}
+
+	if port := strings.TrimSpace(confMap.Data[tlsPortConst]); port != "" {
+		if tlsConf.port, err = strconv.Atoi(port); err != nil || tlsConf.port <= 0 || tlsConf.port > 65535 {
+			return nil, fmt.Errorf("invalid TLS port %q", port)
+		}
+	}
+	if certFile := strings.TrimSpace(confMap.Data[tlsCertFileConst]); certFile != "" {
+		tlsConf.certFile = certFile
+	}
+	if keyFile := strings.TrimSpace(confMap.Data[tlsKeyFileConst]); keyFile != "" {
+		tlsConf.keyFile = keyFile
+	}
+	tlsConf.secret = strings.TrimSpace(confMap.Data[tlsSecretConst])
+	if minVersion := strings.TrimSpace(confMap.Data[tlsMinVersionConst]); minVersion != "" {
+		version, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid minimum TLS version %q, should be one of 1.0, 1.1, 1.2 or 1.3", minVersion)
+		}
+		tlsConf.minVersion = version
+	}
+	for _, name := range strings.Split(confMap.Data[tlsCipherSuitesConst], ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		suite, ok := cipherSuites[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cipher suite %q", name)
+		}
+		tlsConf.cipherSuites = append(tlsConf.cipherSuites, suite)
+	}
+	return tlsConf, nil
+}
+
+// certificateLoader loads the certificate of the rest server from files or a secret, and reloads it when it is
+// changed so that rotated certificates are served without restarting the operator
+type certificateLoader struct {
+	certFile string
+	keyFile  string
+	secret   string
+
+	mux  sync.RWMutex
+	cert *tls.Certificate
+	// version identifies the files or the secret the current certificate is loaded from
+	version string
+}
+
+func newCertificateLoader(tlsConf *tlsServerConfig) *certificateLoader {
+	return &certificateLoader{certFile: tlsConf.certFile, keyFile: tlsConf.keyFile, secret: tlsConf.secret}
+}
+
+// GetCertificate returns the latest valid certificate, it is used as the GetCertificate of the TLS config
+func (l *certificateLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if err := l.reload(context.Background(), k8sClient); err != nil {
+		// keep serving the last valid certificate
+		logRestServer.Error(err, "Error reloading the TLS certificate of the rest server")
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	if l.cert == nil {
+		return nil, fmt.Errorf("no TLS certificate is loaded")
+	}
+	return l.cert, nil
+}
+
+// reload loads the certificate if the files or the secret is changed since the last load
+func (l *certificateLoader) reload(ctx context.Context, reader client.Reader) error {
+	l.mux.RLock()
+	currentVersion := l.version
+	l.mux.RUnlock()
+
+	var version string
+	var certPEM, keyPEM []byte
+	if l.secret != "" {
+		secret := &corev1.Secret{}
+		err := reader.Get(ctx, types.NamespacedName{Namespace: config.SystemNamespace, Name: l.secret}, secret)
+		if err != nil {
+			return err
+		}
+		version = "secret:" + secret.ResourceVersion
+		if version == currentVersion {
+			return nil
+		}
+		certPEM, keyPEM = secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	} else {
+		certInfo, err := os.Stat(l.certFile)
+		if err != nil {
+			return err
+		}
+		keyInfo, err := os.Stat(l.keyFile)
+		if err != nil {
+			return err
+		}
+		version = fmt.Sprintf("files:%v:%v:%v:%v", certInfo.ModTime().UnixNano(), certInfo.Size(),
+			keyInfo.ModTime().UnixNano(), keyInfo.Size())
+		if version == currentVersion {
+			return nil
+		}
+		if certPEM, err = ioutil.ReadFile(l.certFile); err != nil {
+			return err
+		}
+		if keyPEM, err = ioutil.ReadFile(l.keyFile); err != nil {
+			return err
+		}
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("invalid TLS key pair in %s: %v", l.source(), err)
+	}
+
+	l.mux.Lock()
+	l.cert = &cert
+	l.version = version
+	l.mux.Unlock()
+	logRestServer.Info("Loaded the TLS certificate of the rest server", "source", l.source())
+	return nil
+}
+
+// source returns a description of the files or the secret the certificate is loaded from
+func (l *certificateLoader) source() string {
+	if l.secret != "" {
+		return fmt.Sprintf("secret %s/%s", config.SystemNamespace, l.secret)
+	}
+	return fmt.Sprintf("files %s and %s", l.certFile, l.keyFile)
+}
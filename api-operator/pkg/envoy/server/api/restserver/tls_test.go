@@ -0,0 +1,125 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package restserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"math/big"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func newKeyPair(t *testing.T, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestGetTLSServerConfig(t *testing.T) {
+
+	reader := fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: restServerConfName},
+		Data: map[string]string{tlsPortConst: "8443", tlsMinVersionConst: "1.3",
+			tlsCipherSuitesConst: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+	})
+	tlsConf, err := getTLSServerConfig(context.TODO(), reader)
+	if err != nil {
+		t.Fatalf("valid TLS config should not return an error: %v", err)
+	}
+	if tlsConf.port != 8443 || tlsConf.minVersion != tls.VersionTLS13 || len(tlsConf.cipherSuites) != 1 ||
+		tlsConf.certFile != defaultTLSCertFile {
+		t.Errorf("unexpected TLS config: %v", tlsConf)
+	}
+
+	reader = fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: restServerConfName},
+		Data:       map[string]string{tlsCipherSuitesConst: "TLS_RSA_WITH_RC4_128_SHA"},
+	})
+	if _, err = getTLSServerConfig(context.TODO(), reader); err == nil {
+		t.Error("unsupported cipher suite should return an error")
+	}
+}
+
+func TestCertificateLoaderReload(t *testing.T) {
+
+	certPEM, keyPEM := newKeyPair(t, "operator")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.SystemNamespace, Name: "rest-server-tls"},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	reader := fake.NewFakeClientWithScheme(scheme.Scheme, secret)
+	loader := newCertificateLoader(&tlsServerConfig{secret: "rest-server-tls"})
+	if err := loader.reload(context.TODO(), reader); err != nil {
+		t.Fatalf("valid key pair should be loaded: %v", err)
+	}
+	first := loader.cert
+
+	// rotate the certificate
+	certPEM, keyPEM = newKeyPair(t, "operator-rotated")
+	secret.Data = map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}
+	if err := reader.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.reload(context.TODO(), reader); err != nil {
+		t.Fatalf("rotated key pair should be loaded: %v", err)
+	}
+	if loader.cert == first {
+		t.Error("rotated certificate should be loaded")
+	}
+
+	// invalid key pair
+	secret.Data = map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: []byte("invalid")}
+	if err := reader.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	rotated := loader.cert
+	if err := loader.reload(context.TODO(), reader); err == nil {
+		t.Error("invalid key pair should return an error")
+	}
+	if loader.cert != rotated {
+		t.Error("last valid certificate should be kept when the key pair is invalid")
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package envoy
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+)
+
+// maxSnapshotHistory is the number of previous snapshots kept to compute the changes of the APIs
+const maxSnapshotHistory = 32
+
+// SnapshotEntry is an API in the snapshot
+type SnapshotEntry struct {
+	Namespace string
+	Name      string
+	// Digest of the configmaps of the API
+	Digest string
+}
+
+// Snapshot is an immutable bundle of all the APIs to be served to the MGW Adapter
+type Snapshot struct {
+	// Revision of the snapshot. Increases monotonically when the content of the bundle is changed
+	Revision uint64
+	// ETag is a strong entity tag of the bundle derived from the content of the APIs in the bundle
+	ETag string
+	// Entries are the APIs in the bundle sorted by the namespace and name
+	Entries []SnapshotEntry
+	data    []byte
+	// artifacts are the project zips of the APIs keyed by the namespaced name
+	artifacts map[string][]byte
+	// revisions are the revisions that the APIs last changed keyed by the namespaced name
+	revisions map[string]uint64
+}
+
+// SnapshotChanges are the changes of the APIs since a previous revision of the snapshot
+type SnapshotChanges struct {
+	Revision uint64
+	// FullResync is true if the previous revision is unknown, and all the APIs are listed as added
+	FullResync bool
+	Added      []SnapshotEntry
+	Updated    []SnapshotEntry
+	Removed    []SnapshotEntry
+}
+
+// NewReader returns a reader of the zipped bundle
+func (s *Snapshot) NewReader() *bytes.Reader {
+	return bytes.NewReader(s.data)
+}
+
+// Size returns the size of the zipped bundle in bytes
+func (s *Snapshot) Size() int {
+	return len(s.data)
+}
+
+// GetEntry returns the entry of the given API and whether the API is in the snapshot
+func (s *Snapshot) GetEntry(namespace, name string) (SnapshotEntry, bool) {
+	i := sort.Search(len(s.Entries), func(i int) bool {
+		entry := s.Entries[i]
+		return entry.Namespace > namespace || (entry.Namespace == namespace && entry.Name >= name)
+	})
+	if i < len(s.Entries) && s.Entries[i].Namespace == namespace && s.Entries[i].Name == name {
+		return s.Entries[i], true
+	}
+	return SnapshotEntry{}, false
+}
+
+// GetArtifact returns the project zip of the given API and whether the API is in the snapshot
+func (s *Snapshot) GetArtifact(namespace, name string) ([]byte, bool) {
+	artifact, ok := s.artifacts[namespace+"/"+name]
+	return artifact, ok
+}
+
+// GetRevision returns the revision of the snapshot that the given API last changed or 0 if the API is not in the snapshot
+func (s *Snapshot) GetRevision(namespace, name string) uint64 {
+	return s.revisions[namespace+"/"+name]
+}
+
+var (
+	snapshotMux     sync.RWMutex
+	currentSnapshot *Snapshot
+	// snapshotChanged is closed and replaced when the snapshot is changed
+	snapshotChanged = make(chan struct{})
+	// snapshotHistory are the previous snapshots without the bundle, ordered from the oldest
+	snapshotHistory []*Snapshot
+)
+
+// GetSnapshot returns the latest snapshot of the API bundle or nil if no snapshot is built yet
+func GetSnapshot() *Snapshot {
+	snapshotMux.RLock()
+	defer snapshotMux.RUnlock()
+	return currentSnapshot
+}
+
+// setSnapshot swaps the current snapshot with a new snapshot of the given bundle if the entity tag is changed,
+// and returns the current snapshot. Artifacts are the project zips of the entries keyed by the namespaced name
+func setSnapshot(eTag string, entries []SnapshotEntry, data []byte, artifacts map[string][]byte) *Snapshot {
+	snapshotMux.Lock()
+	defer snapshotMux.Unlock()
+
+	if currentSnapshot != nil && currentSnapshot.ETag == eTag {
+		return currentSnapshot
+	}
+
+	var revision uint64 = 1
+	if currentSnapshot != nil {
+		revision = currentSnapshot.Revision + 1
+	}
+	if currentSnapshot != nil {
+		snapshotHistory = append(snapshotHistory, &Snapshot{Revision: currentSnapshot.Revision,
+			ETag: currentSnapshot.ETag, Entries: currentSnapshot.Entries})
+		if len(snapshotHistory) > maxSnapshotHistory {
+			snapshotHistory = snapshotHistory[len(snapshotHistory)-maxSnapshotHistory:]
+		}
+	}
+
+	previousDigests := make(map[string]string)
+	if currentSnapshot != nil {
+		for _, entry := range currentSnapshot.Entries {
+			previousDigests[entry.Namespace+"/"+entry.Name] = entry.Digest
+		}
+	}
+	revisions := make(map[string]uint64, len(entries))
+	for _, entry := range entries {
+		key := entry.Namespace + "/" + entry.Name
+		// keep the revision of the APIs that are not changed
+		if digest, ok := previousDigests[key]; ok && digest == entry.Digest {
+			revisions[key] = currentSnapshot.revisions[key]
+		} else {
+			revisions[key] = revision
+		}
+	}
+	currentSnapshot = &Snapshot{Revision: revision, ETag: eTag, Entries: entries, data: data,
+		artifacts: artifacts, revisions: revisions}
+	close(snapshotChanged)
+	snapshotChanged = make(chan struct{})
+	return currentSnapshot
+}
+
+// WaitForSnapshot blocks until the revision of the snapshot moves past the given revision or the context is done,
+// and returns the latest snapshot which is nil if no snapshot is built yet
+func WaitForSnapshot(ctx context.Context, revision uint64) *Snapshot {
+	for {
+		snapshotMux.RLock()
+		snapshot, changed := currentSnapshot, snapshotChanged
+		snapshotMux.RUnlock()
+
+		// revision of the client can be greater than the current one if the operator is restarted
+		if snapshot != nil && snapshot.Revision != revision {
+			return snapshot
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return snapshot
+		}
+	}
+}
+
+// GetChanges returns the changes of the APIs in the given snapshot since the given revision
+func GetChanges(snapshot *Snapshot, revision uint64) *SnapshotChanges {
+	changes := &SnapshotChanges{Revision: snapshot.Revision}
+	if revision == snapshot.Revision {
+		return changes
+	}
+
+	previous := getHistory(revision)
+	if previous == nil {
+		changes.FullResync = true
+		changes.Added = snapshot.Entries
+		return changes
+	}
+
+	previousDigests := make(map[string]string, len(previous.Entries))
+	for _, entry := range previous.Entries {
+		previousDigests[entry.Namespace+"/"+entry.Name] = entry.Digest
+	}
+	for _, entry := range snapshot.Entries {
+		key := entry.Namespace + "/" + entry.Name
+		previousDigest, ok := previousDigests[key]
+		if !ok {
+			changes.Added = append(changes.Added, entry)
+		} else if previousDigest != entry.Digest {
+			changes.Updated = append(changes.Updated, entry)
+		}
+		delete(previousDigests, key)
+	}
+	for _, entry := range previous.Entries {
+		if _, ok := previousDigests[entry.Namespace+"/"+entry.Name]; ok {
+			changes.Removed = append(changes.Removed, entry)
+		}
+	}
+	return changes
+}
+
+// getHistory returns the previous snapshot of the given revision or nil if it is not available
+func getHistory(revision uint64) *Snapshot {
+	snapshotMux.RLock()
+	defer snapshotMux.RUnlock()
+
+	for _, snapshot := range snapshotHistory {
+		if snapshot.Revision == revision {
+			return snapshot
+		}
+	}
+	return nil
+}
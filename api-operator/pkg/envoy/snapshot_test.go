@@ -0,0 +1,100 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+package envoy
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSetSnapshot(t *testing.T) {
+
+	first := setSnapshot(`"tag1"`, nil, []byte("bundle1"), nil)
+	if GetSnapshot() != first {
+		t.Error("latest snapshot should be returned")
+	}
+
+	same := setSnapshot(`"tag1"`, nil, []byte("bundle1"), nil)
+	if same != first || same.Revision != first.Revision {
+		t.Error("revision should not be changed when the entity tag is not changed")
+	}
+
+	second := setSnapshot(`"tag2"`, nil, []byte("bundle2"), nil)
+	if second.Revision != first.Revision+1 {
+		t.Errorf("revision should be increased, expected: %v, actual: %v", first.Revision+1, second.Revision)
+	}
+	if second.Size() != len("bundle2") {
+		t.Error("snapshot should contain the given bundle")
+	}
+}
+
+func TestGetChanges(t *testing.T) {
+
+	api1 := SnapshotEntry{Namespace: "default", Name: "api1", Digest: "digest1"}
+	api2 := SnapshotEntry{Namespace: "default", Name: "api2", Digest: "digest2"}
+	api2Updated := SnapshotEntry{Namespace: "default", Name: "api2", Digest: "digest2-updated"}
+	api3 := SnapshotEntry{Namespace: "default", Name: "api3", Digest: "digest3"}
+
+	first := setSnapshot(`"changes1"`, []SnapshotEntry{api1, api2}, nil, nil)
+	second := setSnapshot(`"changes2"`, []SnapshotEntry{api2Updated, api3}, nil, nil)
+
+	changes := GetChanges(second, first.Revision)
+	if changes.FullResync || changes.Revision != second.Revision {
+		t.Error("changes should be computed from the previous revision")
+	}
+	if len(changes.Added) != 1 || changes.Added[0] != api3 {
+		t.Errorf("unexpected added APIs: %v", changes.Added)
+	}
+	if len(changes.Updated) != 1 || changes.Updated[0] != api2Updated {
+		t.Errorf("unexpected updated APIs: %v", changes.Updated)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0] != api1 {
+		t.Errorf("unexpected removed APIs: %v", changes.Removed)
+	}
+
+	changes = GetChanges(second, 0)
+	if !changes.FullResync || len(changes.Added) != 2 {
+		t.Error("all the APIs should be listed as added for an unknown revision")
+	}
+
+	changes = GetChanges(second, second.Revision)
+	if changes.FullResync || len(changes.Added)+len(changes.Updated)+len(changes.Removed) != 0 {
+		t.Error("there should not be any changes for the current revision")
+	}
+}
+
+func TestWaitForSnapshot(t *testing.T) {
+
+	current := setSnapshot(`"wait1"`, nil, nil, nil)
+	if WaitForSnapshot(context.TODO(), current.Revision-1) != current {
+		t.Error("should not block for a previous revision")
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	if WaitForSnapshot(ctx, current.Revision) != current {
+		t.Error("should return the current snapshot when the context is done")
+	}
+
+	go setSnapshot(`"wait2"`, nil, nil, nil)
+	next := WaitForSnapshot(context.TODO(), current.Revision)
+	if next.Revision != current.Revision+1 {
+		t.Errorf("should return the next revision, expected: %v, actual: %v", current.Revision+1, next.Revision)
+	}
+}
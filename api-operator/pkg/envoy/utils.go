@@ -18,15 +18,18 @@ package envoy
 
 import (
 	"archive/zip"
-	"crypto/x509"
 	"encoding/base64"
 	"github.com/ghodss/yaml"
 	"github.com/go-openapi/loads"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/httpclient"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/params"
 	"github.com/wso2/k8s-api-operator/api-operator/pkg/utils"
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"gopkg.in/resty.v1"
 	yaml2 "gopkg.in/yaml.v2"
 	"io"
 	"io/ioutil"
@@ -36,7 +39,9 @@ import (
 	"path/filepath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var logUtil = log.Log.WithName("mgw.envoy.util")
@@ -56,7 +61,6 @@ var dirs = []string{
 	"Interceptors",
 	"libs",
 }
-var certPool *x509.CertPool
 
 // createDirectories will create dirs in current working directory
 func createDirectories(name string) error {
@@ -129,24 +133,38 @@ func loadDefaultSpec() (*v2.APIDefinitionFile, error) {
 }
 
 // getCert gets the public cert of Envoy MGW Adapter when skip verification is false
-func getCert(client *client.Client, mgwCertSecretConf string) error {
+func getCert(client *client.Client, mgwCertSecretConf string) ([]byte, error) {
 	envoyMgwCertSecret := k8s.NewSecret()
 	errCert := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: mgwCertSecretConf},
 		envoyMgwCertSecret)
 	if errCert != nil {
-		return errCert
+		return nil, errCert
 	}
 
 	certName, errCert := maps.OneKey(envoyMgwCertSecret.Data)
 	if errCert != nil {
-		return errCert
+		return nil, errCert
 	}
-	cert := string(envoyMgwCertSecret.Data[certName])
-	certData := []byte(cert)
-	certPool = x509.NewCertPool()
-	certPool.AppendCertsFromPEM(certData)
+	return envoyMgwCertSecret.Data[certName], nil
+}
 
-	return nil
+// getMgwAdapterClient returns the HTTP client of the Envoy MGW Adapter configured in the given configmap
+func getMgwAdapterClient(client *client.Client, envoyMgwConfig *corev1.ConfigMap, mgwCertSecret string) (
+	*resty.Client, error) {
+	insecure, err := strconv.ParseBool(envoyMgwConfig.Data[mgwInsecureSkipVerifyConst])
+	if err != nil {
+		return nil, err
+	}
+	clientConfig := &httpclient.Config{
+		InsecureSkipVerify: insecure,
+		Timeout:            time.Duration(DefaultHttpRequestTimeout) * time.Millisecond,
+	}
+	if !insecure {
+		if clientConfig.CACerts, err = getCert(client, mgwCertSecret); err != nil {
+			return nil, err
+		}
+	}
+	return httpclient.Get(mgwAdapterTargetPrefix+envoyMgwConfig.Data[mgwAdapterHostConst], clientConfig)
 }
 
 func getZipData(config *corev1.ConfigMap) (string, error) {
@@ -191,7 +209,9 @@ func getAuthToken(secret *corev1.Secret) string {
 	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
 }
 
-func getSwaggerData(config *corev1.ConfigMap) (string, func(), error) {
+// getSwaggerData returns the API project zip generated with the swagger in the given configmap, to be deployed to the
+// given gateway environments
+func getSwaggerData(config *corev1.ConfigMap, envs []wso2v1alpha2.DeploymentEnvironment) (string, func(), error) {
 	swaggerFileName, errSwagger := maps.OneKey(config.Data)
 	if errSwagger != nil {
 		logUtil.Error(errSwagger, "Error in the swagger configMap data", "data", config.Data)
@@ -233,7 +253,7 @@ func getSwaggerData(config *corev1.ConfigMap) (string, func(), error) {
 	swaggerDirectory, _ := ioutil.TempDir("", "api-swagger-dir*")
 	apiYamlPath := filepath.Join(swaggerDirectory, filepath.FromSlash(apiYamlFile))
 	swaggerSavePath := filepath.Join(swaggerDirectory, filepath.FromSlash(swaggerDefinitionFile))
-	deploymentEnvPath := filepath.Join(swaggerDirectory, filepath.FromSlash(deploymentEnvFile))
+	deploymentEnvPath := filepath.Join(swaggerDirectory, filepath.FromSlash(utils.DeploymentEnvironmentsFile))
 	errCreateDirectory := createDirectories(swaggerDirectory)
 	if errCreateDirectory != nil {
 		return "", nil, errCreateDirectory
@@ -246,7 +266,11 @@ func getSwaggerData(config *corev1.ConfigMap) (string, func(), error) {
 	if err != nil {
 		return "", nil, err
 	}
-	err = ioutil.WriteFile(deploymentEnvPath, []byte(deploymentEnvFileData), os.ModePerm)
+	deploymentEnvData, err := utils.DeploymentEnvironmentsData(envs)
+	if err != nil {
+		return "", nil, err
+	}
+	err = ioutil.WriteFile(deploymentEnvPath, deploymentEnvData, os.ModePerm)
 	if err != nil {
 		return "", nil, err
 	}
@@ -257,6 +281,38 @@ func getSwaggerData(config *corev1.ConfigMap) (string, func(), error) {
 	return swaggerZipFile, cleanupFunc, nil
 }
 
+// applyDeploymentValues returns the path of an archive with the given API project zip and the given params and certs
+// of the API. The given project zip is returned as it is if the API has no params.
+func applyDeploymentValues(zipFile string, values *params.DeploymentValues) (string, func(), error) {
+	if values == nil {
+		return zipFile, nil, nil
+	}
+	zippedData, err := ioutil.ReadFile(zipFile)
+	if err != nil {
+		return "", nil, err
+	}
+	archiveData, err := values.Archive(zippedData)
+	if err != nil {
+		return "", nil, err
+	}
+	archiveFile, err := ioutil.TempFile("", "api-deployment*.zip")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanupFunc := func() {
+		_ = os.Remove(archiveFile.Name())
+	}
+	_, err = archiveFile.Write(archiveData)
+	if closeErr := archiveFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanupFunc()
+		return "", nil, err
+	}
+	return archiveFile.Name(), cleanupFunc, nil
+}
+
 // ZipFiles compresses one or many files into a single zip archive file.
 // Param 1: filename is the output zip file's name.
 // Param 2: files is a list of files to add to the zip.
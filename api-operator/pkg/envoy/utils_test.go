@@ -100,7 +100,7 @@ func TestGetSwaggerData(t *testing.T) {
 	config.Data = configMapData
 
 	os.Setenv(apiOperatorConfigHome, "../../build/controller_resources")
-	zipFile, cleanupFunc, err := getSwaggerData(config)
+	zipFile, cleanupFunc, err := getSwaggerData(config, nil)
 	defer cleanupFunc()
 	if err != nil {
 		t.Error("getting swagger data file should not return an error")
@@ -117,7 +117,7 @@ func TestGetSwaggerDataForInvalidConfigMap(t *testing.T) {
 	config := k8s.NewConfMap()
 	config.Name = "test-cm"
 
-	zipFile, _, err := getSwaggerData(config)
+	zipFile, _, err := getSwaggerData(config, nil)
 	if err == nil {
 		t.Error("getting swagger data file for invalid config map should return an error")
 	}
@@ -162,13 +162,13 @@ func TestGetCert(t *testing.T) {
 	mgwSecret.Data = secretData
 
 	cl := getFakeClient(mgwSecret)
-	err = getCert(cl, secretName)
+	cert, err := getCert(cl, secretName)
 
-	if err != nil {
+	if err != nil || string(cert) != "sample-value" {
 		t.Error("getting mgw cert for valid values should not return an error")
 	}
 
-	err = getCert(cl, "invalid")
+	_, err = getCert(cl, "invalid")
 
 	if err == nil {
 		t.Error("getting mgw cert for invalid values should return an error")
@@ -185,7 +185,7 @@ func TestGetCertForEmptyData(t *testing.T) {
 	mgwSecret.Namespace = "wso2-system"
 
 	cl := getFakeClient(mgwSecret)
-	err = getCert(cl, secretName)
+	_, err = getCert(cl, secretName)
 
 	if err == nil {
 		t.Error("getting mgw cert for empty data should return an error")
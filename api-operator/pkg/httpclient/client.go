@@ -0,0 +1,148 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"gopkg.in/resty.v1"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config is the configuration of the HTTP client of a target
+type Config struct {
+	// InsecureSkipVerify skips the verification of the certificate of the target
+	InsecureSkipVerify bool
+	// CACerts are the PEM encoded certificates to verify the target. System certificates are used if empty
+	CACerts []byte
+	// Timeout of a request to the target
+	Timeout time.Duration
+}
+
+const (
+	// maxAttempts is the number of attempts, including the first one, of a request failed with a transient error
+	maxAttempts = 3
+	// retryWaitTime is the initial wait time between attempts, doubled on each retry
+	retryWaitTime = 500 * time.Millisecond
+	// retryMaxWaitTime is the maximum wait time between attempts
+	retryMaxWaitTime = 4 * time.Second
+)
+
+type cachedClient struct {
+	config Config
+	client *resty.Client
+}
+
+var (
+	clientsMux sync.Mutex
+	// clients are the HTTP clients keyed by the target
+	clients = make(map[string]*cachedClient)
+)
+
+// Get returns the HTTP client of the given target. The client is built with the given config and reused for the
+// target until the config is changed.
+func Get(target string, config *Config) (*resty.Client, error) {
+	clientsMux.Lock()
+	defer clientsMux.Unlock()
+
+	if cached, ok := clients[target]; ok {
+		if cached.config.equal(config) {
+			return cached.client, nil
+		}
+		closeIdleConnections(cached.client)
+	}
+
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	clients[target] = &cachedClient{config: *config, client: client}
+	return client, nil
+}
+
+// Invalidate removes the HTTP client of the given target, so that a new client is built on the next request
+func Invalidate(target string) {
+	clientsMux.Lock()
+	defer clientsMux.Unlock()
+
+	if cached, ok := clients[target]; ok {
+		closeIdleConnections(cached.client)
+		delete(clients, target)
+	}
+}
+
+// InvalidateWithPrefix removes the HTTP clients of the targets starting with the given prefix
+func InvalidateWithPrefix(prefix string) {
+	clientsMux.Lock()
+	defer clientsMux.Unlock()
+
+	for target, cached := range clients {
+		if strings.HasPrefix(target, prefix) {
+			closeIdleConnections(cached.client)
+			delete(clients, target)
+		}
+	}
+}
+
+func (c *Config) equal(other *Config) bool {
+	return c.InsecureSkipVerify == other.InsecureSkipVerify && bytes.Equal(c.CACerts, other.CACerts) &&
+		c.Timeout == other.Timeout
+}
+
+// newClient returns a HTTP client with its own connection pool. Proxy is resolved from the environment variables
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY (or the lowercase versions). Requests of idempotent methods failed with a
+// transient error are retried with a bounded exponential backoff.
+func newClient(config *Config) (*resty.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+	if !config.InsecureSkipVerify && len(config.CACerts) != 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(config.CACerts) {
+			return nil, errors.New("no valid PEM encoded certificates found in the CA certificates")
+		}
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	// retries are done by the transport, resty retries every request error including non-idempotent requests
+	client := resty.NewWithClient(&http.Client{
+		Transport: &retryTransport{next: transport, timeout: config.Timeout},
+	})
+	return client, nil
+}
+
+func closeIdleConnections(client *resty.Client) {
+	if transport, ok := client.GetClient().Transport.(interface{ CloseIdleConnections() }); ok {
+		transport.CloseIdleConnections()
+	}
+}
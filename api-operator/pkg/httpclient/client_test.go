@@ -0,0 +1,51 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+
+	config := &Config{InsecureSkipVerify: true, Timeout: time.Second}
+	first, err := Get("test-target", config)
+	if err != nil {
+		t.Fatalf("getting the client should not return an error: %v", err)
+	}
+	if second, _ := Get("test-target", &Config{InsecureSkipVerify: true, Timeout: time.Second}); second != first {
+		t.Error("client should be reused when the config is not changed")
+	}
+	if other, _ := Get("other-target", config); other == first {
+		t.Error("clients of different targets should not be shared")
+	}
+
+	changed, _ := Get("test-target", &Config{InsecureSkipVerify: true, Timeout: 2 * time.Second})
+	if changed == first || changed.GetClient().Transport.(*retryTransport).timeout != 2*time.Second {
+		t.Error("client should be rebuilt when the config is changed")
+	}
+
+	Invalidate("test-target")
+	if rebuilt, _ := Get("test-target", &Config{InsecureSkipVerify: true, Timeout: 2 * time.Second}); rebuilt == changed {
+		t.Error("client should be rebuilt after invalidating the target")
+	}
+
+	if _, err := Get("test-target", &Config{CACerts: []byte("invalid")}); err == nil {
+		t.Error("invalid CA certificates should return an error")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+)
+
+// Error is an error of a request to a target. A transient error (5xx, 429, timeouts, refused connections) may
+// succeed when the request is retried, a permanent error (400 validation, 401/403 auth, 409 conflict, TLS
+// verification) requires a change in the API or the configuration.
+type Error struct {
+	// Op describes the operation, e.g. "upload API"
+	Op string
+	// StatusCode of the response, zero if no response was received
+	StatusCode int
+	// Status of the response, empty if no response was received
+	Status string
+	// Transient reports whether retrying the request may succeed
+	Transient bool
+	// Err is the error of sending the request, nil if a response was received
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("unable to %s: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("unable to %s. Status: %s", e.Op, e.Status)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewStatusError returns the error of the given unexpected response of the operation op
+func NewStatusError(op string, resp *resty.Response) error {
+	return &Error{
+		Op:         op,
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
+		Transient:  IsTransientStatus(resp.StatusCode()),
+	}
+}
+
+// NewRequestError returns the error of the operation op when the request could not be sent or the response could
+// not be received
+func NewRequestError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Op: op, Err: err, Transient: isTransientRequestError(err)}
+}
+
+// IsTransientStatus reports whether a response with the given status code may succeed when retried
+func IsTransientStatus(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusRequestTimeout
+}
+
+// IsTransient reports whether the given error is a transient error of a request
+func IsTransient(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Transient
+}
+
+// HasStatus reports whether the given error is an error of a response with the given status code
+func HasStatus(err error, statusCode int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == statusCode
+}
+
+// IsPermanent reports whether the given error is a permanent error of a request
+func IsPermanent(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && !e.Transient
+}
+
+// isTransientRequestError reports whether a request failed with the given error may succeed when retried.
+// Timeouts, refused or reset connections and DNS failures are transient, invalid URLs and TLS verification
+// failures are permanent.
+func isTransientRequestError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalidCert) {
+		return false
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Op == "parse" {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestErrors(t *testing.T) {
+
+	attempts := 0
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client, _ := Get(server.URL, &Config{Timeout: time.Second})
+	resp, err := client.R().Get(server.URL)
+	if err != nil || attempts != maxAttempts {
+		t.Errorf("transient status should be retried %d times, but attempted %d times", maxAttempts, attempts)
+	}
+	if statusErr := NewStatusError("get", resp); !IsTransient(statusErr) || IsPermanent(statusErr) {
+		t.Error("5xx status should be a transient error")
+	}
+
+	attempts = 0
+	status = http.StatusServiceUnavailable
+	if _, err := client.R().SetBody([]byte("{}")).Post(server.URL); err != nil || attempts != 1 {
+		t.Errorf("non-idempotent request should not be retried, but attempted %d times", attempts)
+	}
+
+	attempts = 0
+	resp, err = client.R().SetBody([]byte("{}")).Put(server.URL)
+	if err != nil || attempts != maxAttempts {
+		t.Errorf("idempotent request with a body should be retried %d times, but attempted %d times", maxAttempts,
+			attempts)
+	}
+
+	attempts = 0
+	status = http.StatusConflict
+	resp, _ = client.R().Get(server.URL)
+	if attempts != 1 {
+		t.Errorf("permanent status should not be retried, but attempted %d times", attempts)
+	}
+	if statusErr := NewStatusError("get", resp); !IsPermanent(statusErr) {
+		t.Error("409 status should be a permanent error")
+	}
+
+	if !IsTransient(NewRequestError("get", errors.New("connection refused"))) {
+		t.Error("connection errors should be transient errors")
+	}
+	if IsTransient(errors.New("other")) || IsPermanent(errors.New("other")) {
+		t.Error("errors other than request errors should be neither transient nor permanent")
+	}
+	if NewRequestError("get", nil) != nil {
+		t.Error("request error of a nil error should be nil")
+	}
+}
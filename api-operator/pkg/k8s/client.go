@@ -84,3 +84,14 @@ func Apply(client *client.Client, obj runtime.Object) error {
 	return err
 }
 
+// DeleteIfExists deletes the given k8s object if the object is exists in the k8s cluster
+func DeleteIfExists(client *client.Client, obj runtime.Object) error {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	err := (*client).Delete(context.TODO(), obj)
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		logCnt.Error(err, "Error deleting k8s object", "kind", kind, "object", obj)
+	}
+	return err
+}
@@ -28,6 +28,15 @@ import (
 func HandleDeletion(api *wso2v1alpha2.API, ctx context.Context, requestInfo *common.RequestInfo, finalizer string,
 	handle func(*wso2v1alpha2.API) error) (deleted, finalizerUpdated bool,
 	err error) {
+	return HandleObjectDeletion(ctx, requestInfo, finalizer, func() error {
+		return handle(api)
+	})
+}
+
+// HandleObjectDeletion handles deletion of the object in the request by setting finalizers and executing handle
+// function before deletion
+func HandleObjectDeletion(ctx context.Context, requestInfo *common.RequestInfo, finalizer string,
+	handle func() error) (deleted, finalizerUpdated bool, err error) {
 
 	meta := requestInfo.Object.(v1.ObjectMetaAccessor).GetObjectMeta()
 	if meta.GetDeletionTimestamp().IsZero() {
@@ -48,7 +57,7 @@ func HandleDeletion(api *wso2v1alpha2.API, ctx context.Context, requestInfo *com
 			// handle finalizer
 			requestInfo.Log.V(1).Info("Run finalizer handler before removing the specified finalizer",
 				"finalizer", finalizer, "pending_finalizers", meta.GetFinalizers())
-			if err := handle(api); err != nil {
+			if err := handle(); err != nil {
 				return false, false, err
 			}
 			// remove finalizer
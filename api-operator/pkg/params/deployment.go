@@ -0,0 +1,88 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package params
+
+import (
+	"fmt"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	deploymentDir          = "Deployment"
+	certificatesDir        = "certificates"
+	intermediateParamsFile = "intermediate_params.yaml"
+	sourceArchiveFile      = "SourceArchive.zip"
+)
+
+// Archive returns an archive with the given API project zip and the deployment directory with the configs of the
+// selected environment of the params and the certs
+func (v *DeploymentValues) Archive(zippedData []byte) ([]byte, error) {
+	archiveDir, err := ioutil.TempDir("", "api-deployment-dir*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(archiveDir)
+
+	deploymentPath := filepath.Join(archiveDir, deploymentDir)
+	if err := os.MkdirAll(deploymentPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	paramsContent, err := v.environmentConfig()
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(deploymentPath, intermediateParamsFile), paramsContent, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.CertsCM != nil {
+		if _, err := maps.ManyKeys(v.CertsCM.Data); err != nil {
+			return nil, fmt.Errorf("invalid certs configmap %q: %v", v.CertsCM.Name, err)
+		}
+		certsPath := filepath.Join(deploymentPath, certificatesDir)
+		if err := os.MkdirAll(certsPath, os.ModePerm); err != nil {
+			return nil, err
+		}
+		for fileName, certData := range v.CertsCM.Data {
+			err = ioutil.WriteFile(filepath.Join(certsPath, fileName), []byte(certData), os.ModePerm)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = ioutil.WriteFile(filepath.Join(archiveDir, sourceArchiveFile), zippedData, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveFile, err, cleanupFunc := utils.CreateZipFileFromProject(archiveDir, false)
+	if err != nil {
+		return nil, err
+	}
+	//cleanup the temporary artifacts once consuming the zip file
+	if cleanupFunc != nil {
+		defer cleanupFunc()
+	}
+	return ioutil.ReadFile(archiveFile)
+}
@@ -0,0 +1,196 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package params
+
+import (
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/endpoints"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/maps"
+	specs "github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+const paramsEnvironmentConst = "paramsEnvironment"
+
+// DeploymentValues are the params and certs of an API to be deployed with the API project
+type DeploymentValues struct {
+	// ParamsCM is the configmap of the params of the API. Nil if the API has no params.
+	ParamsCM *corev1.ConfigMap
+	// CertsCM is the configmap of the certs of the API. Nil if the API has no certs.
+	CertsCM *corev1.ConfigMap
+	// Environment is the name of the environment in the params to be used. The first environment is used if empty.
+	Environment string
+	// EndpointSecurity is the security of the production and the sandbox endpoints of the API. Nil if the API has no
+	// endpoint security.
+	EndpointSecurity *endpoints.EndpointSecurity
+
+	// namespace is the namespace of the API
+	namespace string
+	// secrets are the secrets referred by the params and the endpoint security keyed by "<namespace>/<name>"
+	secrets map[string]*corev1.Secret
+}
+
+// GetDeploymentValues returns the params and certs of the given API with the environment of the params to be used,
+// or nil if the API has neither params nor endpoint security. Params and certs configmaps that are not found are
+// treated as absent. The endpoint security is the one in the API spec or the one of the TargetEndpoints referred by
+// the API. The environment is the one in the API spec or the default environment in the controller configs. The
+// secrets referred by the params and the endpoint security are retrieved from the namespace of the API.
+func GetDeploymentValues(client *client.Client, api *wso2v1alpha2.API) (*DeploymentValues, error) {
+	values := &DeploymentValues{Environment: api.Spec.ParamsEnvironment, namespace: api.Namespace}
+	if api.Spec.EndpointSecurity != nil {
+		// the endpoint security in the API spec secures both the production and the sandbox endpoints
+		values.EndpointSecurity = &endpoints.EndpointSecurity{Production: api.Spec.EndpointSecurity,
+			Sandbox: api.Spec.EndpointSecurity}
+	} else {
+		security, err := endpoints.Security(client, api)
+		if err != nil {
+			return nil, err
+		}
+		values.EndpointSecurity = security
+	}
+	if api.Spec.ParamsValues != "" {
+		values.ParamsCM = k8s.NewConfMap()
+		if err := k8s.Get(client, types.NamespacedName{Namespace: api.Namespace, Name: api.Spec.ParamsValues},
+			values.ParamsCM); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			values.ParamsCM = nil
+		}
+	}
+	if values.ParamsCM == nil && values.EndpointSecurity == nil {
+		return nil, nil
+	}
+
+	// params file is required for certs importing as the cert information is available in params.yaml
+	if values.ParamsCM != nil && api.Spec.CertsValues != "" {
+		values.CertsCM = k8s.NewConfMap()
+		if err := k8s.Get(client, types.NamespacedName{Namespace: api.Namespace, Name: api.Spec.CertsValues},
+			values.CertsCM); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			values.CertsCM = nil
+		}
+	}
+
+	if values.ParamsCM != nil && values.Environment == "" {
+		controlConf := k8s.NewConfMap()
+		if err := k8s.Get(client, types.NamespacedName{Namespace: config.SystemNamespace, Name: common.ControllerConfName},
+			controlConf); err != nil {
+			return nil, err
+		}
+		values.Environment = controlConf.Data[paramsEnvironmentConst]
+	}
+
+	if err := values.getSecrets(client); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// EnvironmentConfig returns the configs of the environment with the given name in the given params as YAML. The
+// first environment is used if the name is empty.
+func EnvironmentConfig(paramsData, envName string) ([]byte, error) {
+	env, err := selectEnvironment(paramsData, envName)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(env.Config)
+}
+
+// selectEnvironment returns the environment with the given name in the given params. The first environment is
+// returned if the name is empty.
+func selectEnvironment(paramsData, envName string) (*specs.Environment, error) {
+	apiParams := specs.ApiParams{}
+	if err := yaml.Unmarshal([]byte(paramsData), &apiParams); err != nil {
+		return nil, err
+	}
+
+	if envName == "" {
+		if len(apiParams.Environments) == 0 {
+			return nil, fmt.Errorf("no environments found in the params")
+		}
+		return &apiParams.Environments[0], nil
+	}
+	if env := apiParams.GetEnv(envName); env != nil {
+		return env, nil
+	}
+	names := make([]string, 0, len(apiParams.Environments))
+	for _, e := range apiParams.Environments {
+		names = append(names, e.Name)
+	}
+	return nil, fmt.Errorf("environment %q not found in the params, available environments: [%s]", envName,
+		strings.Join(names, ", "))
+}
+
+// Validate returns an error if the params configmap is invalid, the environment to be used is not in the params or
+// a secret value referred by the params or the endpoint security is not found
+func (v *DeploymentValues) Validate() error {
+	_, err := v.environmentConfig()
+	return err
+}
+
+// environmentConfig returns the configs of the environment to be used as YAML, with the placeholders of the secret
+// values resolved and the endpoint security applied
+func (v *DeploymentValues) environmentConfig() ([]byte, error) {
+	envConfig := map[string]interface{}{}
+	if v.ParamsCM != nil {
+		paramsData, err := v.paramsData()
+		if err != nil {
+			return nil, fmt.Errorf("invalid params configmap %q: %v", v.ParamsCM.Name, err)
+		}
+		env, err := selectEnvironment(paramsData, v.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid params configmap %q: %v", v.ParamsCM.Name, err)
+		}
+		for key, value := range env.Config {
+			resolved, err := v.resolveSecretValues(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid params configmap %q: %v", v.ParamsCM.Name, err)
+			}
+			envConfig[key] = resolved
+		}
+	}
+
+	if v.EndpointSecurity != nil {
+		security, err := v.endpointSecurityConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint security: %v", err)
+		}
+		envConfig[securityConfigKey] = security
+	}
+	return yaml.Marshal(envConfig)
+}
+
+// paramsData returns the params file in the params configmap
+func (v *DeploymentValues) paramsData() (string, error) {
+	paramsFileName, err := maps.OneKey(v.ParamsCM.Data)
+	if err != nil {
+		return "", err
+	}
+	return v.ParamsCM.Data[paramsFileName], nil
+}
@@ -0,0 +1,244 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package params
+
+import (
+	"context"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/config"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/controller/common"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+)
+
+const testParams = `environments:
+  - name: dev
+    configs:
+      endpoints:
+        production:
+          url: http://dev.example.com
+  - name: prod
+    configs:
+      endpoints:
+        production:
+          url: http://prod.example.com
+`
+
+func productionURL(t *testing.T, content []byte) string {
+	configs := struct {
+		Endpoints struct {
+			Production struct {
+				URL string `yaml:"url"`
+			} `yaml:"production"`
+		} `yaml:"endpoints"`
+	}{}
+	if err := yaml.Unmarshal(content, &configs); err != nil {
+		t.Fatalf("expected valid configs but was %v", err)
+	}
+	return configs.Endpoints.Production.URL
+}
+
+func TestEnvironmentConfig(t *testing.T) {
+	content, err := EnvironmentConfig(testParams, "prod")
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if url := productionURL(t, content); url != "http://prod.example.com" {
+		t.Errorf("expected the configs of the prod environment but the endpoint was %q", url)
+	}
+
+	content, err = EnvironmentConfig(testParams, "")
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if url := productionURL(t, content); url != "http://dev.example.com" {
+		t.Errorf("expected the configs of the first environment but the endpoint was %q", url)
+	}
+
+	_, err = EnvironmentConfig(testParams, "staging")
+	if err == nil || !strings.Contains(err.Error(), `"staging"`) {
+		t.Errorf("expected an error naming the missing environment but was %v", err)
+	}
+
+	if _, err = EnvironmentConfig("environments: []", ""); err == nil {
+		t.Error("expected an error for params without environments")
+	}
+}
+
+func TestGetDeploymentValues(t *testing.T) {
+	paramsCM := k8s.NewConfMap()
+	paramsCM.Namespace, paramsCM.Name = "ns", "params-cm"
+	paramsCM.Data = map[string]string{"params.yaml": testParams}
+	controlConf := k8s.NewConfMap()
+	controlConf.Namespace, controlConf.Name = config.SystemNamespace, common.ControllerConfName
+	controlConf.Data = map[string]string{paramsEnvironmentConst: "prod"}
+	var cl client.Client = fake.NewFakeClientWithScheme(scheme.Scheme, []runtime.Object{paramsCM, controlConf}...)
+
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "api"}}
+	values, err := GetDeploymentValues(&cl, api)
+	if err != nil || values != nil {
+		t.Errorf("expected no deployment values for an API without params but was %+v, %v", values, err)
+	}
+
+	api.Spec.ParamsValues = "params-cm"
+	values, err = GetDeploymentValues(&cl, api)
+	if err != nil || values == nil || values.Environment != "prod" || values.CertsCM != nil {
+		t.Fatalf("expected the default environment in the controller configs but was %+v, %v", values, err)
+	}
+
+	api.Spec.ParamsEnvironment = "staging"
+	values, err = GetDeploymentValues(&cl, api)
+	if err != nil || values == nil || values.Environment != "staging" {
+		t.Fatalf("expected the environment in the API spec but was %+v, %v", values, err)
+	}
+	if err := values.Validate(); err == nil {
+		t.Error("expected a validation error for a missing environment")
+	}
+}
+
+func TestSecretValues(t *testing.T) {
+	paramsCM := k8s.NewConfMap()
+	paramsCM.Namespace, paramsCM.Name = "ns", "params-cm"
+	paramsCM.Data = map[string]string{"params.yaml": `environments:
+  - name: dev
+    configs:
+      endpoints:
+        production:
+          url: http://${secret:ns/backend/host}/api
+`}
+	backendSecret := k8s.NewSecret()
+	backendSecret.Namespace, backendSecret.Name = "ns", "backend"
+	backendSecret.Data = map[string][]byte{"host": []byte("backend.example.com"), "username": []byte("admin"),
+		"password": []byte("s3cr3t")}
+	var cl client.Client = fake.NewFakeClientWithScheme(scheme.Scheme, []runtime.Object{paramsCM, backendSecret}...)
+
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "api"}}
+	api.Spec.ParamsValues = "params-cm"
+	api.Spec.ParamsEnvironment = "dev"
+	api.Spec.EndpointSecurity = &wso2v1alpha2.EndpointSecurity{Type: "basic", SecretName: "backend"}
+	values, err := GetDeploymentValues(&cl, api)
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	content, err := values.environmentConfig()
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if url := productionURL(t, content); url != "http://backend.example.com/api" {
+		t.Errorf("expected the resolved secret value in the endpoint but was %q", url)
+	}
+	configs := struct {
+		Security map[string]struct {
+			Type     string `yaml:"type"`
+			Username string `yaml:"username"`
+			Password string `yaml:"password"`
+		} `yaml:"security"`
+	}{}
+	if err := yaml.Unmarshal(content, &configs); err != nil {
+		t.Fatalf("expected valid configs but was %v", err)
+	}
+	if security := configs.Security["production"]; security.Type != "basic" || security.Username != "admin" ||
+		security.Password != "s3cr3t" {
+		t.Errorf("expected the credentials in the secret as the endpoint security but was %+v", security)
+	}
+	if versions := values.SecretVersions(); len(versions) != 1 {
+		t.Errorf("expected the version of the referred secret but was %v", versions)
+	}
+
+	api.Spec.EndpointSecurity.PasswordKey = "missing"
+	values, err = GetDeploymentValues(&cl, api)
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	if err := values.Validate(); err == nil || strings.Contains(err.Error(), "admin") {
+		t.Errorf("expected an error for the missing key without the secret values but was %v", err)
+	}
+
+	paramsCM.Data["params.yaml"] = strings.Replace(paramsCM.Data["params.yaml"], "ns/backend", "other/backend", 1)
+	if err := cl.Update(context.TODO(), paramsCM); err != nil {
+		t.Fatalf("expected no error updating the params but was %v", err)
+	}
+	if _, err := GetDeploymentValues(&cl, api); err == nil {
+		t.Error("expected an error for a secret in another namespace")
+	}
+}
+
+const testSecuredSwagger = `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-production-endpoints:
+  targetEndpoint: products
+x-wso2-sandbox-endpoints:
+  urls:
+    - https://sandbox.example.com
+paths:
+  /products:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func TestTargetEndpointSecurity(t *testing.T) {
+	swaggerCM := k8s.NewConfMap()
+	swaggerCM.Namespace, swaggerCM.Name = "ns", "swagger-cm"
+	swaggerCM.Data = map[string]string{"swagger.yaml": testSecuredSwagger}
+	targetEndpoint := &wso2v1alpha2.TargetEndpoint{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "products"}}
+	targetEndpoint.Spec.EndpointSecurity = &wso2v1alpha2.EndpointSecurity{Type: "basic", SecretName: "backend"}
+	backendSecret := k8s.NewSecret()
+	backendSecret.Namespace, backendSecret.Name = "ns", "backend"
+	backendSecret.Data = map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")}
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(s)
+	var cl client.Client = fake.NewFakeClientWithScheme(s, swaggerCM, targetEndpoint, backendSecret)
+
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "api"}}
+	api.Spec.SwaggerConfigMapName = swaggerCM.Name
+	values, err := GetDeploymentValues(&cl, api)
+	if err != nil || values == nil {
+		t.Fatalf("expected the deployment values of the secured target endpoint but was %v, %v", values, err)
+	}
+	content, err := values.environmentConfig()
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	configs := struct {
+		Security map[string]struct {
+			Type     string `yaml:"type"`
+			Username string `yaml:"username"`
+		} `yaml:"security"`
+	}{}
+	if err := yaml.Unmarshal(content, &configs); err != nil {
+		t.Fatalf("expected valid configs but was %v", err)
+	}
+	if security := configs.Security["production"]; security.Type != "basic" || security.Username != "admin" {
+		t.Errorf("expected the production endpoint secured by the target endpoint but was %+v", security)
+	}
+	if security, ok := configs.Security["sandbox"]; ok {
+		t.Errorf("expected the external sandbox endpoint not to be secured but was %+v", security)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package params
+
+import (
+	"fmt"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"github.com/wso2/k8s-api-operator/api-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"regexp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	securityConfigKey    = "security"
+	defaultUsernameKey   = "username"
+	defaultPasswordKey   = "password"
+	productionEndpoint   = "production"
+	sandboxEndpoint      = "sandbox"
+	secretPlaceholderFmt = "${secret:%s/%s/%s}"
+)
+
+// secretPlaceholder matches the placeholders of secret values in params, i.e. "${secret:<namespace>/<name>/<key>}"
+var secretPlaceholder = regexp.MustCompile(`\$\{secret:([^/}]+)/([^/}]+)/([^/}]+)}`)
+
+// getSecrets retrieves the secrets referred by the params and the endpoint security. Only the secrets in the
+// namespace of the API can be referred.
+func (v *DeploymentValues) getSecrets(client *client.Client) error {
+	namespace := v.namespace
+	v.secrets = make(map[string]*corev1.Secret)
+	getSecret := func(secretNamespace, name, referrer string) error {
+		if secretNamespace != namespace {
+			return fmt.Errorf("secret \"%s/%s\" referred by the %s is not in the namespace %q of the API",
+				secretNamespace, name, referrer, namespace)
+		}
+		secretKey := secretNamespace + "/" + name
+		if _, ok := v.secrets[secretKey]; ok {
+			return nil
+		}
+		secret := k8s.NewSecret()
+		if err := k8s.Get(client, types.NamespacedName{Namespace: secretNamespace, Name: name}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("secret %q referred by the %s is not found", secretKey, referrer)
+			}
+			return err
+		}
+		v.secrets[secretKey] = secret
+		return nil
+	}
+
+	if v.ParamsCM != nil {
+		for _, data := range v.ParamsCM.Data {
+			for _, match := range secretPlaceholder.FindAllStringSubmatch(data, -1) {
+				if err := getSecret(match[1], match[2], "params"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if v.EndpointSecurity != nil {
+		for _, security := range []*wso2v1alpha2.EndpointSecurity{v.EndpointSecurity.Production,
+			v.EndpointSecurity.Sandbox} {
+			if security == nil {
+				continue
+			}
+			if err := getSecret(namespace, security.SecretName, "endpoint security"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SecretVersions returns the resource versions of the secrets referred by the params and the endpoint security keyed
+// by "<namespace>/<name>", to detect the changes of the secret values without exposing them
+func (v *DeploymentValues) SecretVersions() map[string]string {
+	versions := make(map[string]string, len(v.secrets))
+	for key, secret := range v.secrets {
+		versions[key] = secret.ResourceVersion
+	}
+	return versions
+}
+
+// secretValue returns the value of the given key in the given secret. The value is not included in the error.
+func (v *DeploymentValues) secretValue(namespace, name, key string) (string, error) {
+	secret, ok := v.secrets[namespace+"/"+name]
+	if !ok {
+		return "", fmt.Errorf("secret \"%s/%s\" is not found", namespace, name)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q is not found in the secret \"%s/%s\"", key, namespace, name)
+	}
+	return string(value), nil
+}
+
+// resolveSecretValues returns the given params value with the placeholders of secret values in string values
+// replaced with the secret values
+func (v *DeploymentValues) resolveSecretValues(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case string:
+		var resolveErr error
+		resolved := secretPlaceholder.ReplaceAllStringFunc(typedValue, func(placeholder string) string {
+			match := secretPlaceholder.FindStringSubmatch(placeholder)
+			secretValue, err := v.secretValue(match[1], match[2], match[3])
+			if err != nil && resolveErr == nil {
+				resolveErr = fmt.Errorf("unresolved placeholder "+secretPlaceholderFmt+": %v", match[1],
+					match[2], match[3], err)
+			}
+			return secretValue
+		})
+		return resolved, resolveErr
+	case map[interface{}]interface{}:
+		resolvedMap := make(map[interface{}]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			resolved, err := v.resolveSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			resolvedMap[key] = resolved
+		}
+		return resolvedMap, nil
+	case map[string]interface{}:
+		resolvedMap := make(map[string]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			resolved, err := v.resolveSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			resolvedMap[key] = resolved
+		}
+		return resolvedMap, nil
+	case []interface{}:
+		resolvedList := make([]interface{}, 0, len(typedValue))
+		for _, item := range typedValue {
+			resolved, err := v.resolveSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			resolvedList = append(resolvedList, resolved)
+		}
+		return resolvedList, nil
+	default:
+		return value, nil
+	}
+}
+
+// endpointSecurityConfig returns the endpoint security config of params for the secured production and sandbox
+// endpoints with the credentials in the secrets of their endpoint securities
+func (v *DeploymentValues) endpointSecurityConfig() (map[string]interface{}, error) {
+	config := make(map[string]interface{}, 2)
+	for endpointType, security := range map[string]*wso2v1alpha2.EndpointSecurity{
+		productionEndpoint: v.EndpointSecurity.Production,
+		sandboxEndpoint:    v.EndpointSecurity.Sandbox,
+	} {
+		if security == nil {
+			continue
+		}
+		endpointSecurity, err := v.endpointCredentials(security)
+		if err != nil {
+			return nil, err
+		}
+		config[endpointType] = endpointSecurity
+	}
+	return config, nil
+}
+
+// endpointCredentials returns the endpoint security config of params for an endpoint with the given security
+func (v *DeploymentValues) endpointCredentials(security *wso2v1alpha2.EndpointSecurity) (map[string]interface{},
+	error) {
+	usernameKey := security.UsernameKey
+	if usernameKey == "" {
+		usernameKey = defaultUsernameKey
+	}
+	passwordKey := security.PasswordKey
+	if passwordKey == "" {
+		passwordKey = defaultPasswordKey
+	}
+
+	username, err := v.secretValue(v.namespace, security.SecretName, usernameKey)
+	if err != nil {
+		return nil, err
+	}
+	password, err := v.secretValue(v.namespace, security.SecretName, passwordKey)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"enabled":  true,
+		"type":     security.Type,
+		"username": username,
+		"password": password,
+	}, nil
+}
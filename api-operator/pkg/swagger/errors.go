@@ -0,0 +1,58 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package swagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError is an invalid x-wso2 extension of an API definition
+type ValidationError struct {
+	// Location of the extension, e.g. "/pets.get" for an operation. Empty for an API level extension.
+	Location string
+	// Extension is the name of the extension, e.g. "x-wso2-throttling-tier"
+	Extension string
+	// Message describes why the extension is invalid
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Location == "" {
+		return fmt.Sprintf("invalid %s: %s", e.Extension, e.Message)
+	}
+	return fmt.Sprintf("invalid %s of %s: %s", e.Extension, e.Location, e.Message)
+}
+
+// ValidationErrors are the invalid x-wso2 extensions of an API definition
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// add adds a validation error of the given extension if the given error is not nil
+func (errs *ValidationErrors) add(location, extension string, err error) {
+	if err == nil {
+		return
+	}
+	*errs = append(*errs, &ValidationError{Location: location, Extension: extension, Message: err.Error()})
+}
@@ -26,8 +26,16 @@ import (
 var logExt = log.Log.WithName("swagger.extensions")
 
 const (
-
-	ApiBasePathExtension        = "x-wso2-basePath"
+	ApiBasePathExtension         = "x-wso2-basePath"
+	ProductionEndpointsExtension = "x-wso2-production-endpoints"
+	SandboxEndpointsExtension    = "x-wso2-sandbox-endpoints"
+	EndpointsExtension           = "x-wso2-endpoints"
+	ThrottlingTierExtension      = "x-wso2-throttling-tier"
+	CORSExtension                = "x-wso2-cors"
+	AuthHeaderExtension          = "x-wso2-auth-header"
+	DisableSecurityExtension     = "x-wso2-disable-security"
+	RequestInterceptorExtension  = "x-wso2-request-interceptor"
+	ResponseInterceptorExtension = "x-wso2-response-interceptor"
 )
 
 func ApiBasePath(swagger *openapi3.Swagger) string {
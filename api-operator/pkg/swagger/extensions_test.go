@@ -18,6 +18,7 @@ package swagger
 
 import (
 	"github.com/getkin/kin-openapi/openapi3"
+	"strings"
 	"testing"
 )
 
@@ -63,4 +64,140 @@ func TestApiBasePath(t *testing.T) {
 	if apiBasePath != "" {
 		t.Error("getting the api base path for invalid openapi should return empty")
 	}
-}
\ No newline at end of file
+}
+func TestGetExtensions(t *testing.T) {
+	openapiV3 := readFileContent(t, "../../test/swagger/openapi_v3_x_wso2.yaml")
+	openapiV3Result, err := GetSwaggerV3(&openapiV3)
+	if err != nil {
+		t.Fatal("error while reading the swagger file")
+	}
+
+	ext, err := GetExtensions(openapiV3Result)
+	if err != nil {
+		t.Fatalf("getting the extensions of valid openapi should not return an error: %v", err)
+	}
+	if ext.BasePath != "/api/pet/v3" || ext.ThrottlingTier != "Gold" || ext.AuthHeader != "Authorization" ||
+		ext.RequestInterceptor != "petRequestInterceptor" || ext.ResponseInterceptor != "petResponseInterceptor" ||
+		ext.DisableSecurity {
+		t.Errorf("unexpected API level extensions: %+v", ext)
+	}
+	if ext.ProductionEndpoints == nil || len(ext.ProductionEndpoints.URLs) != 2 ||
+		ext.ProductionEndpoints.Type != EndpointTypeLoadBalance {
+		t.Errorf("unexpected production endpoints: %+v", ext.ProductionEndpoints)
+	}
+	if ext.SandboxEndpoints == nil || ext.SandboxEndpoints.Ref != "petstoreSandbox" {
+		t.Errorf("unexpected sandbox endpoints: %+v", ext.SandboxEndpoints)
+	}
+	if sandbox := ext.Endpoints["petstoreSandbox"]; len(ext.Endpoints) != 1 || sandbox == nil ||
+		sandbox.URLs[0] != "http://petstore-sandbox.swagger.io/v1" {
+		t.Errorf("unexpected endpoints defined in %s: %+v", EndpointsExtension, ext.Endpoints)
+	}
+	if ext.CORS == nil || !ext.CORS.Enabled || len(ext.CORS.AllowMethods) != 2 {
+		t.Errorf("unexpected CORS config: %+v", ext.CORS)
+	}
+
+	if len(ext.Operations) != 2 {
+		t.Fatalf("extensions of 2 operations should be returned, got %d", len(ext.Operations))
+	}
+	listPets := ext.Operations[0]
+	if listPets.Path != "/pets" || listPets.Method != "GET" || listPets.ThrottlingTier != "Unlimited" ||
+		listPets.DisableSecurity == nil || !*listPets.DisableSecurity {
+		t.Errorf("unexpected extensions of the operation: %+v", listPets)
+	}
+	showPet := ext.Operations[1]
+	if showPet.Path != "/pets/{petId}" || showPet.DisableSecurity != nil || showPet.ProductionEndpoints == nil ||
+		showPet.ProductionEndpoints.URLs[0] != "https://petstore-3.swagger.io/v1" {
+		t.Errorf("unexpected extensions of the operation: %+v", showPet)
+	}
+}
+
+func TestGetExtensionsInvalid(t *testing.T) {
+	openapiV3 := readFileContent(t, "../../test/swagger/openapi_v3_x_wso2_invalid.yaml")
+	openapiV3Result, err := GetSwaggerV3(&openapiV3)
+	if err != nil {
+		t.Fatal("error while reading the swagger file")
+	}
+
+	_, err = GetExtensions(openapiV3Result)
+	validationErrs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("getting the extensions of invalid openapi should return validation errors, got: %v", err)
+	}
+
+	expected := map[string]bool{
+		ProductionEndpointsExtension:                 false,
+		SandboxEndpointsExtension:                    false,
+		DisableSecurityExtension:                     false,
+		"/pets.get/" + ProductionEndpointsExtension:  false,
+		"/pets.post/" + ProductionEndpointsExtension: false,
+	}
+	for _, validationErr := range validationErrs {
+		key := validationErr.Extension
+		if validationErr.Location != "" {
+			key = validationErr.Location + "/" + key
+		}
+		if _, ok := expected[key]; !ok {
+			t.Errorf("unexpected validation error: %v", validationErr)
+		}
+		expected[key] = true
+	}
+	for key, found := range expected {
+		if !found {
+			t.Errorf("validation error of %s is not returned", key)
+		}
+	}
+}
+
+func TestGetExtensionsEndpointRefs(t *testing.T) {
+	openapiV3 := `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-production-endpoints: "#/x-wso2-endpoints/products"
+x-wso2-sandbox-endpoints: "#/x-wso2-endpoints/productsSandbox"
+x-wso2-endpoints:
+  - products:
+      targetEndpoint: products
+  - invalid:
+      urls:
+        - products.example.com
+paths:
+  /products:
+    get:
+      x-wso2-production-endpoints: "#/x-wso2-endpoints/invalid"
+      responses:
+        '200':
+          description: OK
+`
+	openapiV3Result, err := GetSwaggerV3(&openapiV3)
+	if err != nil {
+		t.Fatal("error while reading the swagger file")
+	}
+
+	ext, err := GetExtensions(openapiV3Result)
+	validationErrs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("getting the extensions with dangling references should return validation errors, got: %v", err)
+	}
+	if len(ext.Endpoints) != 1 || ext.Endpoints["products"] == nil ||
+		ext.Endpoints["products"].TargetEndpoint != "products" {
+		t.Errorf("unexpected endpoints defined in %s: %+v", EndpointsExtension, ext.Endpoints)
+	}
+
+	expected := []string{
+		EndpointsExtension,
+		SandboxEndpointsExtension,
+		"/products.get/" + ProductionEndpointsExtension,
+	}
+	var keys []string
+	for _, validationErr := range validationErrs {
+		key := validationErr.Extension
+		if validationErr.Location != "" {
+			key = validationErr.Location + "/" + key
+		}
+		keys = append(keys, key)
+	}
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected the validation errors of %v but was %v", expected, validationErrs)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+const (
+	// VersionSwagger2 is the version of Swagger 2.0 definitions
+	VersionSwagger2 = "2.0"
+	// VersionOpenAPI30 is the version of OpenAPI 3.0.x definitions
+	VersionOpenAPI30 = "3.0"
+	// VersionOpenAPI31 is the version of OpenAPI 3.1.x definitions
+	VersionOpenAPI31 = "3.1"
+)
+
+// Format is the format of an API definition
+type Format struct {
+	// JSON is true if the definition is written in JSON and false if it is written in YAML
+	JSON bool
+	// Version of the specification of the definition. One of VersionSwagger2, VersionOpenAPI30 or VersionOpenAPI31.
+	Version string
+	// SpecVersion is the version of the specification declared in the definition, e.g. "3.0.1". Empty if the
+	// definition does not declare a version.
+	SpecVersion string
+}
+
+// DetectFormat returns the format of the given API definition. A definition without a "swagger" or "openapi"
+// version is treated as a Swagger 2.0 definition.
+func DetectFormat(data []byte) (*Format, error) {
+	format, _, err := detectFormat(data)
+	return format, err
+}
+
+// detectFormat returns the format of the given API definition and the definition as JSON
+func detectFormat(data []byte) (*Format, []byte, error) {
+	format := &Format{JSON: bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))}
+	jsonData := data
+	if !format.JSON {
+		var err error
+		if jsonData, err = yaml.YAMLToJSON(data); err != nil {
+			return nil, nil, fmt.Errorf("invalid YAML API definition: %v", err)
+		}
+	}
+
+	var versions struct {
+		Swagger json.RawMessage `json:"swagger"`
+		OpenAPI json.RawMessage `json:"openapi"`
+	}
+	if err := json.Unmarshal(jsonData, &versions); err != nil {
+		return nil, nil, fmt.Errorf("invalid API definition: %v", err)
+	}
+
+	switch {
+	case versions.OpenAPI != nil:
+		format.SpecVersion = versionString(versions.OpenAPI)
+		switch majorMinor(format.SpecVersion) {
+		case VersionOpenAPI30:
+			format.Version = VersionOpenAPI30
+		case VersionOpenAPI31:
+			format.Version = VersionOpenAPI31
+		default:
+			return nil, nil, fmt.Errorf("unsupported OpenAPI version %q, supported versions: 3.0.x, 3.1.x",
+				format.SpecVersion)
+		}
+	case versions.Swagger != nil:
+		format.SpecVersion = versionString(versions.Swagger)
+		if majorMinor(format.SpecVersion) != VersionSwagger2 {
+			return nil, nil, fmt.Errorf("unsupported Swagger version %q, supported version: 2.0",
+				format.SpecVersion)
+		}
+		format.Version = VersionSwagger2
+	default:
+		format.Version = VersionSwagger2
+	}
+	return format, jsonData, nil
+}
+
+// versionString returns the version in the given JSON value. Versions are numbers if they are not quoted in YAML.
+func versionString(value json.RawMessage) string {
+	var version string
+	if err := json.Unmarshal(value, &version); err != nil {
+		version = string(value)
+		if !strings.Contains(version, ".") {
+			// "2.0" is converted to the number 2
+			version += ".0"
+		}
+	}
+	return version
+}
+
+// majorMinor returns the "<major>.<minor>" of the given version, e.g. "3.0" of "3.0.1" and "2.0" of "2"
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) == 1 {
+		return parts[0] + ".0"
+	}
+	return parts[0] + "." + parts[1]
+}
@@ -0,0 +1,329 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// EndpointTypeLoadBalance distributes the requests among the URLs of the endpoints
+	EndpointTypeLoadBalance = "loadbalance"
+	// EndpointTypeFailover sends the requests to the next URL of the endpoints when a URL fails
+	EndpointTypeFailover = "failover"
+
+	endpointRefPrefix = "#/x-wso2-endpoints/"
+)
+
+// Extensions are the x-wso2 extensions of an API definition
+type Extensions struct {
+	BasePath            string
+	ProductionEndpoints *Endpoints
+	SandboxEndpoints    *Endpoints
+	// Endpoints are the endpoints defined in "x-wso2-endpoints" by their names, which are referred by the
+	// production and sandbox endpoints of the API and the operations
+	Endpoints           map[string]*Endpoints
+	ThrottlingTier      string
+	CORS                *CORSConfig
+	AuthHeader          string
+	DisableSecurity     bool
+	RequestInterceptor  string
+	ResponseInterceptor string
+	// Operations are the extensions of the operations overriding the API level extensions. Only the operations with
+	// extensions are included, sorted by the path and the method.
+	Operations []*OperationExtensions
+}
+
+// OperationExtensions are the x-wso2 extensions of an operation of an API definition
+type OperationExtensions struct {
+	// Path of the operation, e.g. "/pets/{petId}"
+	Path string
+	// Method of the operation in upper case, e.g. "GET"
+	Method              string
+	ProductionEndpoints *Endpoints
+	SandboxEndpoints    *Endpoints
+	ThrottlingTier      string
+	// DisableSecurity is nil if the operation does not override the security of the API
+	DisableSecurity     *bool
+	RequestInterceptor  string
+	ResponseInterceptor string
+}
+
+// Endpoints are the backend endpoints of an API or an operation
+type Endpoints struct {
+	// Ref is the name of the endpoints defined in "x-wso2-endpoints" if the endpoints are referred,
+	// e.g. "myEndpoint" of "#/x-wso2-endpoints/myEndpoint"
+	Ref  string   `json:"-"`
+	URLs []string `json:"urls"`
+	// Type of the endpoints, "loadbalance" or "failover". Empty for a single endpoint.
+	Type string `json:"type,omitempty"`
+	// TargetEndpoint is the name of a TargetEndpoint in the namespace of the API resolved as the endpoint. URLs
+	// may also refer TargetEndpoints in the form "k8s://<namespace>/<name>[:<port>]".
+	TargetEndpoint string `json:"targetEndpoint,omitempty"`
+	// EndpointGroup is the name of an EndpointGroup in the namespace of the API resolved as the URLs and the type
+	// of the endpoints
+	EndpointGroup string `json:"endpointGroup,omitempty"`
+}
+
+// CORSConfig is the CORS configuration of an API
+type CORSConfig struct {
+	Enabled          bool     `json:"corsConfigurationEnabled"`
+	AllowOrigins     []string `json:"accessControlAllowOrigins,omitempty"`
+	AllowCredentials bool     `json:"accessControlAllowCredentials,omitempty"`
+	AllowHeaders     []string `json:"accessControlAllowHeaders,omitempty"`
+	AllowMethods     []string `json:"accessControlAllowMethods,omitempty"`
+}
+
+// GetExtensions returns the x-wso2 extensions of the given API definition. The returned error is ValidationErrors
+// with all the invalid extensions if any extension is invalid.
+func GetExtensions(swagger *openapi3.Swagger) (*Extensions, error) {
+	var errs ValidationErrors
+	ext := &Extensions{}
+	props := swagger.Extensions
+
+	errs.add("", ApiBasePathExtension, decodeExtension(props, ApiBasePathExtension, &ext.BasePath))
+	ext.ProductionEndpoints = decodeEndpoints(props, "", ProductionEndpointsExtension, &errs)
+	ext.SandboxEndpoints = decodeEndpoints(props, "", SandboxEndpointsExtension, &errs)
+	ext.Endpoints = decodeEndpointsDefinitions(props, &errs)
+	errs.add("", ThrottlingTierExtension, decodeExtension(props, ThrottlingTierExtension, &ext.ThrottlingTier))
+	errs.add("", AuthHeaderExtension, decodeExtension(props, AuthHeaderExtension, &ext.AuthHeader))
+	errs.add("", DisableSecurityExtension, decodeExtension(props, DisableSecurityExtension, &ext.DisableSecurity))
+	errs.add("", RequestInterceptorExtension,
+		decodeExtension(props, RequestInterceptorExtension, &ext.RequestInterceptor))
+	errs.add("", ResponseInterceptorExtension,
+		decodeExtension(props, ResponseInterceptorExtension, &ext.ResponseInterceptor))
+	if _, ok := props[CORSExtension]; ok {
+		ext.CORS = &CORSConfig{}
+		if err := decodeExtension(props, CORSExtension, ext.CORS); err != nil {
+			errs.add("", CORSExtension, err)
+			ext.CORS = nil
+		}
+	}
+
+	paths := make([]string, 0, len(swagger.Paths))
+	for path := range swagger.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		operations := swagger.Paths[path].Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			if opExt := getOperationExtensions(path, method, operations[method], &errs); opExt != nil {
+				ext.Operations = append(ext.Operations, opExt)
+			}
+		}
+	}
+
+	validateEndpointRefs(ext, &errs)
+	if len(errs) != 0 {
+		return ext, errs
+	}
+	return ext, nil
+}
+
+// decodeEndpointsDefinitions returns the endpoints defined in "x-wso2-endpoints" by their names, or nil if the
+// extension is not found. The extension is a list of objects with the names of the endpoints as the keys, e.g.
+//
+//	x-wso2-endpoints:
+//	  - myEndpoint:
+//	      urls:
+//	        - http://example.com
+func decodeEndpointsDefinitions(props map[string]interface{}, errs *ValidationErrors) map[string]*Endpoints {
+	var definitions []map[string]json.RawMessage
+	if err := decodeExtension(props, EndpointsExtension, &definitions); err != nil {
+		errs.add("", EndpointsExtension, err)
+		return nil
+	}
+	if definitions == nil {
+		return nil
+	}
+
+	endpointsByName := make(map[string]*Endpoints)
+	for _, definition := range definitions {
+		for name := range definition {
+			if _, ok := endpointsByName[name]; ok {
+				errs.add("", EndpointsExtension, fmt.Errorf("endpoints %q are defined more than once", name))
+				continue
+			}
+			endpoints := &Endpoints{}
+			if err := json.Unmarshal(definition[name], endpoints); err != nil {
+				errs.add("", EndpointsExtension, fmt.Errorf("invalid endpoints %q: %v", name, err))
+				continue
+			}
+			if err := endpoints.validate(); err != nil {
+				errs.add("", EndpointsExtension, fmt.Errorf("invalid endpoints %q: %v", name, err))
+				continue
+			}
+			endpointsByName[name] = endpoints
+		}
+	}
+	return endpointsByName
+}
+
+// validateEndpointRefs adds a validation error for each production and sandbox endpoints of the API and the
+// operations referring endpoints not defined in "x-wso2-endpoints"
+func validateEndpointRefs(ext *Extensions, errs *ValidationErrors) {
+	validate := func(location, name string, endpoints *Endpoints) {
+		if endpoints == nil || endpoints.Ref == "" {
+			return
+		}
+		if _, ok := ext.Endpoints[endpoints.Ref]; !ok {
+			errs.add(location, name, fmt.Errorf("referred endpoints %q are not defined in %s", endpoints.Ref,
+				EndpointsExtension))
+		}
+	}
+
+	validate("", ProductionEndpointsExtension, ext.ProductionEndpoints)
+	validate("", SandboxEndpointsExtension, ext.SandboxEndpoints)
+	for _, opExt := range ext.Operations {
+		location := opExt.Path + "." + strings.ToLower(opExt.Method)
+		validate(location, ProductionEndpointsExtension, opExt.ProductionEndpoints)
+		validate(location, SandboxEndpointsExtension, opExt.SandboxEndpoints)
+	}
+}
+
+// getOperationExtensions returns the x-wso2 extensions of the given operation, or nil if the operation has no
+// x-wso2 extensions
+func getOperationExtensions(path, method string, operation *openapi3.Operation,
+	errs *ValidationErrors) *OperationExtensions {
+	props := operation.Extensions
+	hasExtensions := false
+	for name := range props {
+		if strings.HasPrefix(name, "x-wso2-") {
+			hasExtensions = true
+			break
+		}
+	}
+	if !hasExtensions {
+		return nil
+	}
+
+	location := path + "." + strings.ToLower(method)
+	opExt := &OperationExtensions{Path: path, Method: method}
+	opExt.ProductionEndpoints = decodeEndpoints(props, location, ProductionEndpointsExtension, errs)
+	opExt.SandboxEndpoints = decodeEndpoints(props, location, SandboxEndpointsExtension, errs)
+	errs.add(location, ThrottlingTierExtension, decodeExtension(props, ThrottlingTierExtension, &opExt.ThrottlingTier))
+	errs.add(location, RequestInterceptorExtension,
+		decodeExtension(props, RequestInterceptorExtension, &opExt.RequestInterceptor))
+	errs.add(location, ResponseInterceptorExtension,
+		decodeExtension(props, ResponseInterceptorExtension, &opExt.ResponseInterceptor))
+	if _, ok := props[DisableSecurityExtension]; ok {
+		disableSecurity := false
+		if err := decodeExtension(props, DisableSecurityExtension, &disableSecurity); err != nil {
+			errs.add(location, DisableSecurityExtension, err)
+		} else {
+			opExt.DisableSecurity = &disableSecurity
+		}
+	}
+	return opExt
+}
+
+// decodeEndpoints returns the endpoints in the given extension, or nil if the extension is not found or invalid.
+// Endpoints are either defined inline or referred from "x-wso2-endpoints".
+func decodeEndpoints(props map[string]interface{}, location, name string, errs *ValidationErrors) *Endpoints {
+	if _, ok := props[name]; !ok {
+		return nil
+	}
+
+	var ref string
+	if err := decodeExtension(props, name, &ref); err == nil {
+		if !strings.HasPrefix(ref, endpointRefPrefix) || ref == endpointRefPrefix {
+			errs.add(location, name, fmt.Errorf("invalid reference %q, expected \"%s<name>\"", ref,
+				endpointRefPrefix))
+			return nil
+		}
+		return &Endpoints{Ref: strings.TrimPrefix(ref, endpointRefPrefix)}
+	}
+
+	endpoints := &Endpoints{}
+	if err := decodeExtension(props, name, endpoints); err != nil {
+		errs.add(location, name, err)
+		return nil
+	}
+	if err := endpoints.validate(); err != nil {
+		errs.add(location, name, err)
+		return nil
+	}
+	return endpoints
+}
+
+// validate returns an error if the inline endpoints are invalid
+func (e *Endpoints) validate() error {
+	if e.TargetEndpoint != "" {
+		if len(e.URLs) != 0 || e.EndpointGroup != "" {
+			return fmt.Errorf("only one of the target endpoint, endpoint group and endpoint URLs can be defined")
+		}
+		return nil
+	}
+	if e.EndpointGroup != "" {
+		if len(e.URLs) != 0 || e.Type != "" {
+			return fmt.Errorf("endpoint URLs and type can not be defined with the endpoint group")
+		}
+		return nil
+	}
+	if len(e.URLs) == 0 {
+		return fmt.Errorf("no endpoint URLs are defined")
+	}
+	if e.Type != "" && e.Type != EndpointTypeLoadBalance && e.Type != EndpointTypeFailover {
+		return fmt.Errorf("unsupported endpoint type %q, expected %q or %q", e.Type, EndpointTypeLoadBalance,
+			EndpointTypeFailover)
+	}
+	for _, endpointURL := range e.URLs {
+		if IsTargetEndpointURL(endpointURL) {
+			if _, err := ParseTargetEndpointURL(endpointURL); err != nil {
+				return err
+			}
+			continue
+		}
+		u, err := url.Parse(endpointURL)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint URL %q: %v", endpointURL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid endpoint URL %q, expected an absolute http or https URL", endpointURL)
+		}
+	}
+	return nil
+}
+
+// decodeExtension decodes the value of the given extension to the target. The target is not changed if the
+// extension is not found.
+func decodeExtension(props map[string]interface{}, name string, target interface{}) error {
+	value, ok := props[name]
+	if !ok {
+		return nil
+	}
+	data, ok := value.(json.RawMessage)
+	if !ok {
+		// extensions set in the model instead of loaded from a definition are not raw messages
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, target)
+}
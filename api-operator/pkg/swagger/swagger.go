@@ -17,45 +17,194 @@
 package swagger
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/getkin/kin-openapi/openapi2"
 	"github.com/getkin/kin-openapi/openapi2conv"
 	"github.com/getkin/kin-openapi/openapi3"
-	"gopkg.in/yaml.v2"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strings"
 )
 
 var logger = log.Log.WithName("swagger")
 
-// GetSwaggerV3 returns the openapi3.Swagger of given swagger string
+// GetSwaggerV3 returns the openapi3.Swagger of given swagger string. Swagger 2.0 definitions are converted to
+// OpenAPI 3.0 and OpenAPI 3.1 definitions are loaded with the OpenAPI 3.0 model.
 func GetSwaggerV3(swaggerStr *string) (*openapi3.Swagger, error) {
-	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(*swaggerStr))
+	format, jsonData, err := detectFormat([]byte(*swaggerStr))
 	if err != nil {
-		return GetSwaggerV2(swaggerStr)
+		return nil, err
 	}
+	logger.Info("Swagger version", "version", format.SpecVersion, "json", format.JSON)
 
-	swaggerV3Version := swagger.OpenAPI
-	logger.Info("Swagger version", "version", swaggerV3Version)
-
-	if swaggerV3Version != "" {
-		return swagger, err
-	} else {
+	switch format.Version {
+	case VersionOpenAPI30, VersionOpenAPI31:
+		return loadOpenAPIV3(jsonData, format)
+	default:
 		logger.Info("OpenAPI v3 not found. Hence converting Swagger v2 to Swagger v3")
-		return GetSwaggerV2(swaggerStr)
+		return convertSwaggerV2(jsonData)
 	}
 }
 
-// GetSwaggerV2 returns the openapi2.Swagger of given swagger string
+// GetSwaggerV2 returns the openapi3.Swagger of given swagger v2 string converted to OpenAPI 3.0
 func GetSwaggerV2(swaggerStr *string) (*openapi3.Swagger, error) {
+	_, jsonData, err := detectFormat([]byte(*swaggerStr))
+	if err != nil {
+		return nil, err
+	}
+	return convertSwaggerV2(jsonData)
+}
+
+// loadOpenAPIV3 loads the given OpenAPI 3.x definition in JSON
+func loadOpenAPIV3(jsonData []byte, format *Format) (*openapi3.Swagger, error) {
+	doc, err := decodeJSON(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	// versions which are not quoted in YAML are numbers
+	doc["openapi"] = format.SpecVersion
+	if format.Version == VersionOpenAPI31 {
+		downgradeOpenAPI31(doc)
+	}
+	if jsonData, err = json.Marshal(doc); err != nil {
+		return nil, err
+	}
+	return openapi3.NewSwaggerLoader().LoadSwaggerFromData(jsonData)
+}
+
+// downgradeOpenAPI31 rewrites the JSON schema keywords of OpenAPI 3.1 which are not supported by the OpenAPI 3.0
+// model. Type arrays are replaced with the single type with "nullable", and numeric exclusive bounds are replaced
+// with the bound and the boolean exclusive flag. Values of the extensions are not changed.
+func downgradeOpenAPI31(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if types, ok := v["type"].([]interface{}); ok {
+			var nonNullTypes []interface{}
+			for _, t := range types {
+				if t == "null" {
+					v["nullable"] = true
+				} else {
+					nonNullTypes = append(nonNullTypes, t)
+				}
+			}
+			if len(nonNullTypes) == 1 {
+				v["type"] = nonNullTypes[0]
+			} else {
+				// multiple types can not be represented in OpenAPI 3.0
+				delete(v, "type")
+			}
+		}
+		for exclusiveKey, boundKey := range map[string]string{
+			"exclusiveMinimum": "minimum",
+			"exclusiveMaximum": "maximum",
+		} {
+			if bound, ok := v[exclusiveKey].(json.Number); ok {
+				v[boundKey] = bound
+				v[exclusiveKey] = true
+			}
+		}
+		for key, child := range v {
+			if !strings.HasPrefix(key, "x-") {
+				downgradeOpenAPI31(child)
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			downgradeOpenAPI31(child)
+		}
+	}
+}
+
+// convertSwaggerV2 converts the given Swagger 2.0 definition in JSON to OpenAPI 3.0 with the extensions of the
+// definition, paths, operations and security definitions
+func convertSwaggerV2(jsonData []byte) (*openapi3.Swagger, error) {
 	var swagger2 openapi2.Swagger
-	// ignore error
-	_ = json.Unmarshal([]byte(*swaggerStr), &swagger2)
-	if swagger2.BasePath == "" {
-		if err := yaml.Unmarshal([]byte(*swaggerStr), &swagger2); err != nil {
-			return nil, err
+	if err := json.Unmarshal(jsonData, &swagger2); err != nil {
+		return nil, err
+	}
+	swaggerV3, err := openapi2conv.ToV3Swagger(&swagger2)
+	if err != nil {
+		return nil, err
+	}
+	if err := copySwaggerV2Extensions(jsonData, swaggerV3); err != nil {
+		return nil, err
+	}
+	return swaggerV3, nil
+}
+
+// copySwaggerV2Extensions copies the extensions of the given Swagger 2.0 definition in JSON to the converted
+// definition, as the Swagger 2.0 model does not keep extensions
+func copySwaggerV2Extensions(jsonData []byte, swaggerV3 *openapi3.Swagger) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return err
+	}
+	addExtensions(&swaggerV3.ExtensionProps, doc)
+
+	var paths map[string]map[string]json.RawMessage
+	if err := unmarshalIfExists(doc["paths"], &paths); err != nil {
+		return err
+	}
+	for path, pathDoc := range paths {
+		pathItem := swaggerV3.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		addExtensions(&pathItem.ExtensionProps, pathDoc)
+		operations := pathItem.Operations()
+		for method, operationData := range pathDoc {
+			operation := operations[strings.ToUpper(method)]
+			if operation == nil {
+				continue
+			}
+			var operationDoc map[string]json.RawMessage
+			if err := json.Unmarshal(operationData, &operationDoc); err != nil {
+				return err
+			}
+			addExtensions(&operation.ExtensionProps, operationDoc)
 		}
 	}
 
-	swaggerV3, err2 := openapi2conv.ToV3Swagger(&swagger2)
-	return swaggerV3, err2
+	var securityDefinitions map[string]map[string]json.RawMessage
+	if err := unmarshalIfExists(doc["securityDefinitions"], &securityDefinitions); err != nil {
+		return err
+	}
+	for name, securityDoc := range securityDefinitions {
+		if scheme := swaggerV3.Components.SecuritySchemes[name]; scheme != nil && scheme.Value != nil {
+			addExtensions(&scheme.Value.ExtensionProps, securityDoc)
+		}
+	}
+	return nil
+}
+
+// addExtensions adds the "x-" properties of the given object to the given extension properties
+func addExtensions(props *openapi3.ExtensionProps, object map[string]json.RawMessage) {
+	for key, value := range object {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if props.Extensions == nil {
+			props.Extensions = make(map[string]interface{})
+		}
+		props.Extensions[key] = value
+	}
+}
+
+// unmarshalIfExists unmarshals the given JSON value to the target if the value exists
+func unmarshalIfExists(data json.RawMessage, target interface{}) error {
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// decodeJSON decodes the given JSON object keeping the numbers as json.Number
+func decodeJSON(jsonData []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
 }
@@ -89,4 +89,132 @@ func readFileContent(t *testing.T, path string) string {
 		t.Error("error while reading the openapi file")
 	}
 	return string(data)
-}
\ No newline at end of file
+}
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		json    bool
+		version string
+		wantErr bool
+	}{
+		{name: "swagger v2 json", data: readFileContent(t, "../../test/swagger/swagger_v2.json"), json: true,
+			version: VersionSwagger2},
+		{name: "swagger v2 yaml", data: readFileContent(t, "../../test/swagger/swagger_v2.yaml"),
+			version: VersionSwagger2},
+		{name: "swagger v2 yaml with unquoted version",
+			data: readFileContent(t, "../../test/swagger/swagger_v2_x_wso2.yaml"), version: VersionSwagger2},
+		{name: "openapi v3.0 yaml", data: readFileContent(t, "../../test/swagger/openapi_v3.yaml"),
+			version: VersionOpenAPI30},
+		{name: "openapi v3.1 yaml", data: readFileContent(t, "../../test/swagger/openapi_v3_1.yaml"),
+			version: VersionOpenAPI31},
+		{name: "openapi v3.0 json", data: `{"openapi": "3.0.2", "info": {"title": "Pets", "version": "1.0.0"}}`,
+			json: true, version: VersionOpenAPI30},
+		{name: "without version", data: readFileContent(t, "../../test/swagger/openapi_v3_invalid.yaml"),
+			version: VersionSwagger2},
+		{name: "unsupported openapi version", data: "openapi: 4.0.0", wantErr: true},
+		{name: "unsupported swagger version", data: `{"swagger": "1.2"}`, json: true, wantErr: true},
+		{name: "invalid definition", data: "Invalid OpenAPI", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format, err := DetectFormat([]byte(test.data))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("detecting the format should return an error, got: %+v", format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("detecting the format should not return an error: %v", err)
+			}
+			if format.JSON != test.json || format.Version != test.version {
+				t.Errorf("unexpected format: %+v", format)
+			}
+		})
+	}
+}
+
+func TestGetSwaggerV3ForSwaggerV2Extensions(t *testing.T) {
+	swaggerV2 := readFileContent(t, "../../test/swagger/swagger_v2_x_wso2.yaml")
+	swaggerV3, err := GetSwaggerV3(&swaggerV2)
+	if err != nil {
+		t.Fatalf("getting the openapi v3 for valid swagger v2 should not return an error: %v", err)
+	}
+
+	if basePath := ApiBasePath(swaggerV3); basePath != "/pizzashack/v2" {
+		t.Errorf("base path extension should be preserved, got: %q", basePath)
+	}
+	if _, ok := swaggerV3.Paths["/menu"].Extensions["x-wso2-path-owner"]; !ok {
+		t.Error("extensions of the path should be preserved")
+	}
+	if _, ok := swaggerV3.Components.SecuritySchemes["default"].Value.Extensions["x-scopes-bindings"]; !ok {
+		t.Error("extensions of the security definition should be preserved")
+	}
+
+	ext, err := GetExtensions(swaggerV3)
+	if err != nil {
+		t.Fatalf("getting the extensions of valid swagger v2 should not return an error: %v", err)
+	}
+	if ext.ThrottlingTier != "Gold" || ext.ProductionEndpoints == nil ||
+		ext.ProductionEndpoints.URLs[0] != "https://pizzashack-1.com/api" {
+		t.Errorf("unexpected API level extensions: %+v", ext)
+	}
+	if len(ext.Operations) != 1 || ext.Operations[0].ThrottlingTier != "Unlimited" ||
+		ext.Operations[0].DisableSecurity == nil || !*ext.Operations[0].DisableSecurity {
+		t.Errorf("extensions of the operation should be preserved, got: %+v", ext.Operations)
+	}
+}
+
+func TestGetSwaggerV3ForSwaggerV2JSON(t *testing.T) {
+	swaggerV2 := readFileContent(t, "../../test/swagger/swagger_v2.json")
+	swaggerV3, err := GetSwaggerV3(&swaggerV2)
+	if err != nil {
+		t.Fatalf("getting the openapi v3 for valid swagger v2 should not return an error: %v", err)
+	}
+
+	if basePath := ApiBasePath(swaggerV3); basePath != "/pizzashack/v1" {
+		t.Errorf("base path extension should be preserved, got: %q", basePath)
+	}
+	operation := swaggerV3.Paths["/menu"].Get
+	if operation == nil || operation.OperationID != "getMenu" {
+		t.Fatalf("operation of the swagger v2 should be converted, got: %+v", operation)
+	}
+	if _, ok := operation.Extensions[ThrottlingTierExtension]; !ok {
+		t.Error("extensions of the operation should be preserved")
+	}
+}
+
+func TestGetSwaggerV3ForOpenAPIV31(t *testing.T) {
+	openapiV31 := readFileContent(t, "../../test/swagger/openapi_v3_1.yaml")
+	openapiV3, err := GetSwaggerV3(&openapiV31)
+	if err != nil {
+		t.Fatalf("getting the openapi v3 for valid openapi v3.1 should not return an error: %v", err)
+	}
+
+	if openapiV3.OpenAPI != "3.1.0" {
+		t.Errorf("version of the openapi should be preserved, got: %q", openapiV3.OpenAPI)
+	}
+	if basePath := ApiBasePath(openapiV3); basePath != "/api/pet/v3.1" {
+		t.Errorf("base path extension should be preserved, got: %q", basePath)
+	}
+
+	tag := openapiV3.Components.Schemas["Pet"].Value.Properties["tag"].Value
+	if tag.Type != "string" || !tag.Nullable {
+		t.Errorf("nullable type of openapi v3.1 should be converted, got type: %q, nullable: %v", tag.Type,
+			tag.Nullable)
+	}
+	limit := openapiV3.Paths["/pets"].Get.Parameters[0].Value.Schema.Value
+	if !limit.ExclusiveMin || limit.Min == nil || *limit.Min != 0 {
+		t.Errorf("numeric exclusive minimum of openapi v3.1 should be converted, got: %+v", limit)
+	}
+
+	ext, err := GetExtensions(openapiV3)
+	if err != nil {
+		t.Fatalf("getting the extensions of valid openapi v3.1 should not return an error: %v", err)
+	}
+	if len(ext.Operations) != 1 || ext.Operations[0].ThrottlingTier != "Unlimited" {
+		t.Errorf("unexpected extensions of the operations: %+v", ext.Operations)
+	}
+}
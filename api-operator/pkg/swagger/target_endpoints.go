@@ -0,0 +1,313 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TargetEndpointURLPrefix is the prefix of the endpoint URLs referring TargetEndpoints
+const TargetEndpointURLPrefix = "k8s://"
+
+// TargetEndpointRef is a reference to a TargetEndpoint in the endpoints of an API definition
+type TargetEndpointRef struct {
+	// Namespace of the TargetEndpoint. Empty for the namespace of the API.
+	Namespace string
+	// Name of the TargetEndpoint
+	Name string
+	// Port of the service of the TargetEndpoint. Zero for the first port of the TargetEndpoint.
+	Port int32
+}
+
+func (ref *TargetEndpointRef) String() string {
+	s := ref.Name
+	if ref.Namespace != "" {
+		s = ref.Namespace + "/" + s
+	}
+	if ref.Port != 0 {
+		s += ":" + strconv.Itoa(int(ref.Port))
+	}
+	return s
+}
+
+// IsTargetEndpointURL returns true if the given endpoint URL refers a TargetEndpoint
+func IsTargetEndpointURL(endpointURL string) bool {
+	return strings.HasPrefix(endpointURL, TargetEndpointURLPrefix)
+}
+
+// ParseTargetEndpointURL returns the TargetEndpoint referred by the given URL in the form
+// "k8s://<namespace>/<name>[:<port>]"
+func ParseTargetEndpointURL(endpointURL string) (*TargetEndpointRef, error) {
+	invalidErr := fmt.Errorf("invalid target endpoint URL %q, expected \"%s<namespace>/<name>[:<port>]\"",
+		endpointURL, TargetEndpointURLPrefix)
+	parts := strings.Split(strings.TrimPrefix(endpointURL, TargetEndpointURLPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, invalidErr
+	}
+
+	ref := &TargetEndpointRef{Namespace: parts[0], Name: parts[1]}
+	if i := strings.LastIndex(parts[1], ":"); i >= 0 {
+		port, err := strconv.ParseInt(parts[1][i+1:], 10, 32)
+		if err != nil || port <= 0 {
+			return nil, invalidErr
+		}
+		ref.Name = parts[1][:i]
+		ref.Port = int32(port)
+	}
+	if ref.Name == "" {
+		return nil, invalidErr
+	}
+	return ref, nil
+}
+
+// EndpointResolver resolves the TargetEndpoints and the EndpointGroups referred by the endpoints of an API definition
+type EndpointResolver interface {
+	// TargetEndpointURL returns the URL of the referred TargetEndpoint
+	TargetEndpointURL(ref *TargetEndpointRef) (string, error)
+	// EndpointGroup returns the URLs and the type of the endpoints of the EndpointGroup with the given name in the
+	// namespace of the API
+	EndpointGroup(name string) (*Endpoints, error)
+}
+
+// refCollector is an EndpointResolver collecting the references in the order they are resolved
+type refCollector struct {
+	targetEndpoints []*TargetEndpointRef
+	endpointGroups  []string
+}
+
+func (c *refCollector) TargetEndpointURL(ref *TargetEndpointRef) (string, error) {
+	c.targetEndpoints = append(c.targetEndpoints, ref)
+	return "", nil
+}
+
+func (c *refCollector) EndpointGroup(name string) (*Endpoints, error) {
+	c.endpointGroups = append(c.endpointGroups, name)
+	return &Endpoints{}, nil
+}
+
+// EndpointRefsOf returns the TargetEndpoints and the names of the EndpointGroups referred by the endpoints of the
+// given extension, ProductionEndpointsExtension or SandboxEndpointsExtension, of the API and the operations in the
+// given API definition, including the endpoints referred from "x-wso2-endpoints", in the order they are referred
+func EndpointRefsOf(definition, extension string) ([]*TargetEndpointRef, []string, error) {
+	_, jsonData, err := detectFormat([]byte(definition))
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := decodeJSON(jsonData)
+	if err != nil {
+		return nil, nil, err
+	}
+	collector := &refCollector{}
+	for _, endpoints := range endpointsObjects(doc, true, extension) {
+		if _, err := resolveEndpointsObject(endpoints, collector); err != nil {
+			return nil, nil, err
+		}
+	}
+	return collector.targetEndpoints, collector.endpointGroups, nil
+}
+
+// EndpointRefs returns the TargetEndpoints and the names of the EndpointGroups referred by the production and
+// sandbox endpoints of the API and the operations and the endpoints defined in "x-wso2-endpoints" in the given API
+// definition, in the order they are referred
+func EndpointRefs(definition string) ([]*TargetEndpointRef, []string, error) {
+	collector := &refCollector{}
+	_, _, _, err := resolveEndpoints(definition, collector)
+	return collector.targetEndpoints, collector.endpointGroups, err
+}
+
+// ResolveEndpoints returns the given API definition with the endpoints referring TargetEndpoints and EndpointGroups
+// replaced with the endpoints returned by the given resolver. The definition is returned as it is if no endpoint
+// refers a TargetEndpoint or an EndpointGroup.
+func ResolveEndpoints(definition string, resolver EndpointResolver) (string, error) {
+	format, doc, resolved, err := resolveEndpoints(definition, resolver)
+	if err != nil || !resolved {
+		return definition, err
+	}
+
+	// versions which are not quoted in YAML are numbers
+	for _, key := range []string{"swagger", "openapi"} {
+		if _, ok := doc[key]; ok {
+			doc[key] = format.SpecVersion
+		}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	if !format.JSON {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return "", err
+		}
+	}
+	return string(data), nil
+}
+
+// resolveEndpoints decodes the given API definition and replaces the endpoints referring TargetEndpoints and
+// EndpointGroups with the endpoints returned by the given resolver. Returns true if any endpoint is replaced.
+func resolveEndpoints(definition string, resolver EndpointResolver) (*Format, map[string]interface{}, bool, error) {
+	format, jsonData, err := detectFormat([]byte(definition))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	doc, err := decodeJSON(jsonData)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	objects := endpointsObjects(doc, false, ProductionEndpointsExtension, SandboxEndpointsExtension)
+	// endpoints defined in "x-wso2-endpoints" in the order they are defined
+	definitions, _ := doc[EndpointsExtension].([]interface{})
+	for _, definition := range definitions {
+		definitionObject, ok := definition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, name := range sortedKeys(definitionObject) {
+			if endpoints, ok := definitionObject[name].(map[string]interface{}); ok {
+				objects = append(objects, endpoints)
+			}
+		}
+	}
+
+	resolved := false
+	for _, endpoints := range objects {
+		endpointsResolved, err := resolveEndpointsObject(endpoints, resolver)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		resolved = resolved || endpointsResolved
+	}
+	return format, doc, resolved, nil
+}
+
+// endpointsObjects returns the endpoints of the given extensions of the API and the operations sorted by the path
+// and the method. If followRefs is true, the endpoints in "x-wso2-endpoints" referred by the extensions are returned
+// in place of the references, each of them once.
+func endpointsObjects(doc map[string]interface{}, followRefs bool, extensions ...string) []map[string]interface{} {
+	objects := []map[string]interface{}{doc}
+	if paths, ok := doc["paths"].(map[string]interface{}); ok {
+		for _, path := range sortedKeys(paths) {
+			pathItem, ok := paths[path].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, method := range sortedKeys(pathItem) {
+				if operation, ok := pathItem[method].(map[string]interface{}); ok {
+					objects = append(objects, operation)
+				}
+			}
+		}
+	}
+
+	var endpointsList []map[string]interface{}
+	followed := make(map[string]bool)
+	for _, object := range objects {
+		for _, name := range extensions {
+			switch endpoints := object[name].(type) {
+			case map[string]interface{}:
+				endpointsList = append(endpointsList, endpoints)
+			case string:
+				ref := strings.TrimPrefix(endpoints, endpointRefPrefix)
+				if !followRefs || ref == endpoints || followed[ref] {
+					continue
+				}
+				followed[ref] = true
+				if definition := endpointsDefinition(doc, ref); definition != nil {
+					endpointsList = append(endpointsList, definition)
+				}
+			}
+		}
+	}
+	return endpointsList
+}
+
+// endpointsDefinition returns the endpoints with the given name defined in "x-wso2-endpoints", or nil if they are
+// not defined
+func endpointsDefinition(doc map[string]interface{}, name string) map[string]interface{} {
+	definitions, _ := doc[EndpointsExtension].([]interface{})
+	for _, definition := range definitions {
+		definitionObject, ok := definition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if endpoints, ok := definitionObject[name].(map[string]interface{}); ok {
+			return endpoints
+		}
+	}
+	return nil
+}
+
+// resolveEndpointsObject replaces the given endpoints referring TargetEndpoints and EndpointGroups with the
+// endpoints returned by the given resolver. Returns true if the endpoints are replaced.
+func resolveEndpointsObject(endpoints map[string]interface{}, resolver EndpointResolver) (bool, error) {
+	if targetEndpoint, ok := endpoints["targetEndpoint"].(string); ok && targetEndpoint != "" {
+		endpointURL, err := resolver.TargetEndpointURL(&TargetEndpointRef{Name: targetEndpoint})
+		if err != nil {
+			return false, err
+		}
+		delete(endpoints, "targetEndpoint")
+		endpoints["urls"] = []interface{}{endpointURL}
+		return true, nil
+	}
+	if endpointGroup, ok := endpoints["endpointGroup"].(string); ok && endpointGroup != "" {
+		groupEndpoints, err := resolver.EndpointGroup(endpointGroup)
+		if err != nil {
+			return false, err
+		}
+		delete(endpoints, "endpointGroup")
+		urls := make([]interface{}, 0, len(groupEndpoints.URLs))
+		for _, endpointURL := range groupEndpoints.URLs {
+			urls = append(urls, endpointURL)
+		}
+		endpoints["urls"] = urls
+		if groupEndpoints.Type != "" {
+			endpoints["type"] = groupEndpoints.Type
+		}
+		return true, nil
+	}
+	resolved := false
+	urls, _ := endpoints["urls"].([]interface{})
+	for i, u := range urls {
+		endpointURL, ok := u.(string)
+		if !ok || !IsTargetEndpointURL(endpointURL) {
+			continue
+		}
+		ref, err := ParseTargetEndpointURL(endpointURL)
+		if err != nil {
+			return false, err
+		}
+		if urls[i], err = resolver.TargetEndpointURL(ref); err != nil {
+			return false, err
+		}
+		resolved = true
+	}
+	return resolved, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
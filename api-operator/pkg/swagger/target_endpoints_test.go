@@ -0,0 +1,206 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package swagger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const targetEndpointsSwagger = `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-production-endpoints:
+  targetEndpoint: products
+x-wso2-sandbox-endpoints:
+  urls:
+    - http://sandbox.example.com
+    - k8s://backends/products-sandbox:8080
+paths:
+  /products:
+    get:
+      x-wso2-production-endpoints:
+        urls:
+          - k8s://backends/products-list
+      responses:
+        '200':
+          description: OK
+  /products/{id}:
+    get:
+      x-wso2-production-endpoints:
+        endpointGroup: products-dr
+      responses:
+        '200':
+          description: OK
+`
+
+// testResolver resolves TargetEndpoints to URLs with the reference and EndpointGroups to failover endpoints
+type testResolver struct{}
+
+func (r *testResolver) TargetEndpointURL(ref *TargetEndpointRef) (string, error) {
+	return "http://resolved/" + ref.String(), nil
+}
+
+func (r *testResolver) EndpointGroup(name string) (*Endpoints, error) {
+	return &Endpoints{URLs: []string{"http://primary/" + name, "http://secondary/" + name},
+		Type: EndpointTypeFailover}, nil
+}
+
+// failingResolver fails resolving any reference
+type failingResolver struct{}
+
+func (r *failingResolver) TargetEndpointURL(ref *TargetEndpointRef) (string, error) {
+	return "", fmt.Errorf("unexpected reference: %v", ref)
+}
+
+func (r *failingResolver) EndpointGroup(name string) (*Endpoints, error) {
+	return nil, fmt.Errorf("unexpected endpoint group: %s", name)
+}
+
+func TestParseTargetEndpointURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		ref     TargetEndpointRef
+		wantErr bool
+	}{
+		{url: "k8s://ns/products", ref: TargetEndpointRef{Namespace: "ns", Name: "products"}},
+		{url: "k8s://ns/products:8080", ref: TargetEndpointRef{Namespace: "ns", Name: "products", Port: 8080}},
+		{url: "k8s://products", wantErr: true},
+		{url: "k8s:///products", wantErr: true},
+		{url: "k8s://ns/", wantErr: true},
+		{url: "k8s://ns/products:http", wantErr: true},
+		{url: "k8s://ns/products:0", wantErr: true},
+		{url: "k8s://ns/products/v1", wantErr: true},
+	}
+
+	for _, test := range tests {
+		ref, err := ParseTargetEndpointURL(test.url)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parsing %q should return an error, got: %+v", test.url, ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsing %q should not return an error: %v", test.url, err)
+			continue
+		}
+		if *ref != test.ref {
+			t.Errorf("unexpected reference of %q: %+v", test.url, ref)
+		}
+	}
+}
+
+func TestResolveEndpoints(t *testing.T) {
+	refs, groups, err := EndpointRefs(targetEndpointsSwagger)
+	if err != nil {
+		t.Fatalf("getting the endpoint references should not return an error: %v", err)
+	}
+	var refStrings []string
+	for _, ref := range refs {
+		refStrings = append(refStrings, ref.String())
+	}
+	if strings.Join(refStrings, ",") != "products,backends/products-sandbox:8080,backends/products-list" {
+		t.Errorf("unexpected target endpoint references: %v", refStrings)
+	}
+	if len(groups) != 1 || groups[0] != "products-dr" {
+		t.Errorf("unexpected endpoint group references: %v", groups)
+	}
+
+	resolved, err := ResolveEndpoints(targetEndpointsSwagger, &testResolver{})
+	if err != nil {
+		t.Fatalf("resolving the endpoints should not return an error: %v", err)
+	}
+	swagger, err := GetSwaggerV3(&resolved)
+	if err != nil {
+		t.Fatalf("resolved definition should be valid: %v", err)
+	}
+	ext, err := GetExtensions(swagger)
+	if err != nil {
+		t.Fatalf("extensions of the resolved definition should be valid: %v", err)
+	}
+	if ext.ProductionEndpoints.TargetEndpoint != "" || ext.ProductionEndpoints.URLs[0] != "http://resolved/products" {
+		t.Errorf("unexpected production endpoints: %+v", ext.ProductionEndpoints)
+	}
+	if ext.SandboxEndpoints.URLs[0] != "http://sandbox.example.com" ||
+		ext.SandboxEndpoints.URLs[1] != "http://resolved/backends/products-sandbox:8080" {
+		t.Errorf("unexpected sandbox endpoints: %+v", ext.SandboxEndpoints)
+	}
+	if ext.Operations[0].ProductionEndpoints.URLs[0] != "http://resolved/backends/products-list" {
+		t.Errorf("unexpected production endpoints of the operation: %+v", ext.Operations[0].ProductionEndpoints)
+	}
+	if groupEndpoints := ext.Operations[1].ProductionEndpoints; groupEndpoints.EndpointGroup != "" ||
+		groupEndpoints.Type != EndpointTypeFailover || len(groupEndpoints.URLs) != 2 ||
+		groupEndpoints.URLs[0] != "http://primary/products-dr" {
+		t.Errorf("unexpected production endpoints of the endpoint group: %+v", groupEndpoints)
+	}
+
+	withoutRefs := readFileContent(t, "../../test/swagger/openapi_v3_x_wso2.yaml")
+	resolved, err = ResolveEndpoints(withoutRefs, &failingResolver{})
+	if err != nil || resolved != withoutRefs {
+		t.Errorf("definition without references should be returned as it is, got error: %v", err)
+	}
+}
+
+func TestResolveEndpointsDefinitions(t *testing.T) {
+	definition := `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Products
+x-wso2-production-endpoints: "#/x-wso2-endpoints/products"
+x-wso2-sandbox-endpoints: "#/x-wso2-endpoints/productsSandbox"
+x-wso2-endpoints:
+  - products:
+      targetEndpoint: products
+  - productsSandbox:
+      endpointGroup: products-dr
+paths: {}
+`
+	refs, groups, err := EndpointRefs(definition)
+	if err != nil {
+		t.Fatalf("getting the endpoint references should not return an error: %v", err)
+	}
+	if len(refs) != 1 || refs[0].String() != "products" || len(groups) != 1 || groups[0] != "products-dr" {
+		t.Errorf("unexpected references in %s: %v, %v", EndpointsExtension, refs, groups)
+	}
+
+	resolved, err := ResolveEndpoints(definition, &testResolver{})
+	if err != nil {
+		t.Fatalf("resolving the endpoints should not return an error: %v", err)
+	}
+	swagger, err := GetSwaggerV3(&resolved)
+	if err != nil {
+		t.Fatalf("resolved definition should be valid: %v", err)
+	}
+	ext, err := GetExtensions(swagger)
+	if err != nil {
+		t.Fatalf("extensions of the resolved definition should be valid: %v", err)
+	}
+	if ext.ProductionEndpoints.Ref != "products" {
+		t.Errorf("references to %s should be kept, got: %+v", EndpointsExtension, ext.ProductionEndpoints)
+	}
+	if products := ext.Endpoints["products"]; products.TargetEndpoint != "" ||
+		len(products.URLs) != 1 || products.URLs[0] != "http://resolved/products" {
+		t.Errorf("unexpected resolved endpoints of the target endpoint: %+v", products)
+	}
+	if sandbox := ext.Endpoints["productsSandbox"]; sandbox.EndpointGroup != "" ||
+		sandbox.Type != EndpointTypeFailover || len(sandbox.URLs) != 2 {
+		t.Errorf("unexpected resolved endpoints of the endpoint group: %+v", sandbox)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DeploymentEnvironmentsFile is the file of an API project with the gateway environments to deploy the API
+	DeploymentEnvironmentsFile = "deployment_environments.yaml"
+	// DefaultDeploymentEnvironment is the gateway environment of an API if no environments are specified
+	DefaultDeploymentEnvironment = "Default"
+
+	deploymentEnvironmentsType    = "deployment_environments"
+	deploymentEnvironmentsVersion = "v4.0.0"
+)
+
+type deploymentEnvironments struct {
+	Type    string                  `yaml:"type"`
+	Version string                  `yaml:"version"`
+	Data    []deploymentEnvironment `yaml:"data"`
+}
+
+type deploymentEnvironment struct {
+	DisplayOnDevportal    bool   `yaml:"displayOnDevportal"`
+	DeploymentEnvironment string `yaml:"deploymentEnvironment"`
+}
+
+// GetDeploymentEnvironments returns the given gateway environments, or the default environment if empty
+func GetDeploymentEnvironments(envs []wso2v1alpha2.DeploymentEnvironment) []wso2v1alpha2.DeploymentEnvironment {
+	if len(envs) == 0 {
+		return []wso2v1alpha2.DeploymentEnvironment{{Name: DefaultDeploymentEnvironment, DisplayOnDevportal: true}}
+	}
+	return envs
+}
+
+// DeploymentEnvironmentsData returns the content of the deployment environments file of an API project with the
+// given gateway environments, or the default environment if empty
+func DeploymentEnvironmentsData(envs []wso2v1alpha2.DeploymentEnvironment) ([]byte, error) {
+	file := deploymentEnvironments{Type: deploymentEnvironmentsType, Version: deploymentEnvironmentsVersion}
+	for _, env := range GetDeploymentEnvironments(envs) {
+		file.Data = append(file.Data, deploymentEnvironment{
+			DisplayOnDevportal:    env.DisplayOnDevportal,
+			DeploymentEnvironment: env.Name,
+		})
+	}
+	return yaml.Marshal(file)
+}
+
+// OverrideDeploymentEnvironments returns the path of an API project zip with the deployment environments file of the
+// given project zip replaced with the given gateway environments. The given project zip is returned as it is if no
+// environments are given.
+func OverrideDeploymentEnvironments(zipFile string, envs []wso2v1alpha2.DeploymentEnvironment) (string, func(),
+	error) {
+	if len(envs) == 0 {
+		return zipFile, nil, nil
+	}
+	projectDir, err := ExtractArchive(zipFile)
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(projectDir))
+
+	deploymentEnvData, err := DeploymentEnvironmentsData(envs)
+	if err != nil {
+		return "", nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(projectDir, DeploymentEnvironmentsFile), deploymentEnvData,
+		os.ModePerm)
+	if err != nil {
+		return "", nil, err
+	}
+	overriddenZipFile, err, cleanupFunc := CreateZipFileFromProject(projectDir, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return overriddenZipFile, cleanupFunc, nil
+}
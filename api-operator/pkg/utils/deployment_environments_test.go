@@ -0,0 +1,89 @@
+// Copyright (c)  WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// WSO2 Inc. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeploymentEnvironmentsData(t *testing.T) {
+	data, err := DeploymentEnvironmentsData(nil)
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	file := deploymentEnvironments{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("expected a valid yaml but was %v", err)
+	}
+	if file.Type != deploymentEnvironmentsType || len(file.Data) != 1 ||
+		file.Data[0].DeploymentEnvironment != DefaultDeploymentEnvironment || !file.Data[0].DisplayOnDevportal {
+		t.Errorf("expected the default environment but was %+v", file)
+	}
+
+	data, err = DeploymentEnvironmentsData([]wso2v1alpha2.DeploymentEnvironment{
+		{Name: "internal"},
+		{Name: "external", DisplayOnDevportal: true},
+	})
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	file = deploymentEnvironments{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("expected a valid yaml but was %v", err)
+	}
+	if len(file.Data) != 2 || file.Data[0].DeploymentEnvironment != "internal" || file.Data[0].DisplayOnDevportal ||
+		file.Data[1].DeploymentEnvironment != "external" || !file.Data[1].DisplayOnDevportal {
+		t.Errorf("expected the given environments but was %+v", file)
+	}
+}
+
+func TestOverrideDeploymentEnvironments(t *testing.T) {
+	zipFile := "../../test/utils/PizzaShackAPI_1.0.0.zip"
+	overriddenZipFile, cleanupFunc, err := OverrideDeploymentEnvironments(zipFile, nil)
+	if err != nil || overriddenZipFile != zipFile || cleanupFunc != nil {
+		t.Errorf("expected the same project zip without environments but was %q, %v", overriddenZipFile, err)
+	}
+
+	overriddenZipFile, cleanupFunc, err = OverrideDeploymentEnvironments(zipFile,
+		[]wso2v1alpha2.DeploymentEnvironment{{Name: "internal"}})
+	if err != nil {
+		t.Fatalf("expected no error but was %v", err)
+	}
+	defer cleanupFunc()
+
+	projectDir, err := ExtractArchive(overriddenZipFile)
+	if err != nil {
+		t.Fatalf("expected a valid project zip but was %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(projectDir))
+	data, err := ioutil.ReadFile(filepath.Join(projectDir, DeploymentEnvironmentsFile))
+	if err != nil {
+		t.Fatalf("expected the deployment environments file in the project but was %v", err)
+	}
+	file := deploymentEnvironments{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("expected a valid yaml but was %v", err)
+	}
+	if len(file.Data) != 1 || file.Data[0].DeploymentEnvironment != "internal" {
+		t.Errorf("expected the given environments but was %+v", file)
+	}
+}